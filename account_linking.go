@@ -0,0 +1,300 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLinkAccountMessage is spoken to customers who use an intent requiring
+// a linked account before linking one.
+const DefaultLinkAccountMessage = "Please use the Alexa app to link your account."
+
+// DefaultIntrospectionTimeout bounds how long an AccountLinker waits for its
+// Introspector so a slow OAuth provider cannot hold a response past the
+// deadline Alexa places on skills.
+const DefaultIntrospectionTimeout = 2 * time.Second
+
+// ErrAccountNotLinked is returned when a request does not carry an access
+// token for a linked account.
+var ErrAccountNotLinked = errors.New("account not linked")
+
+// TokenInfo describes the access token of a linked account as reported by the
+// OAuth provider.
+type TokenInfo struct {
+	// Active reports whether the token is valid and has not been revoked.
+	Active bool
+	// Subject identifies the account at the OAuth provider.
+	Subject string
+	// Scope is the space separated list of scopes granted to the token.
+	Scope string
+	// Expiry is when the token expires. The zero value means the provider
+	// did not say.
+	Expiry time.Time
+}
+
+// HasScope reports whether the scope was granted to the token.
+func (i *TokenInfo) HasScope(scope string) bool {
+	for _, s := range strings.Fields(i.Scope) {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// A TokenIntrospector validates the access token of a linked account with the
+// OAuth provider that issued it.
+type TokenIntrospector interface {
+	Introspect(ctx context.Context, token string) (*TokenInfo, error)
+}
+
+// An OAuthIntrospector validates access tokens with an OAuth 2.0 token
+// introspection endpoint as described by RFC 7662. The endpoint is
+// authenticated with the client credentials when ClientID is set.
+type OAuthIntrospector struct {
+	URL          string
+	ClientID     string
+	ClientSecret string
+	HTTPClient   *http.Client
+}
+
+// NewOAuthIntrospector returns an OAuthIntrospector for the introspection
+// endpoint at introspectionURL.
+func NewOAuthIntrospector(introspectionURL, clientID, clientSecret string) *OAuthIntrospector {
+	return &OAuthIntrospector{
+		URL:          introspectionURL,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		HTTPClient:   http.DefaultClient,
+	}
+}
+
+// Introspect asks the provider whether the token is active.
+func (i *OAuthIntrospector) Introspect(ctx context.Context, token string) (*TokenInfo, error) {
+	form := url.Values{"token": {token}}
+
+	r, err := http.NewRequest(http.MethodPost, i.URL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	r = r.WithContext(ctx)
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("Accept", "application/json")
+	if i.ClientID != "" {
+		r.SetBasicAuth(i.ClientID, i.ClientSecret)
+	}
+
+	client := i.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, newServiceError(resp)
+	}
+
+	var payload struct {
+		Active bool   `json:"active"`
+		Sub    string `json:"sub"`
+		Scope  string `json:"scope"`
+		Exp    int64  `json:"exp"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, err
+	}
+
+	info := &TokenInfo{Active: payload.Active, Subject: payload.Sub, Scope: payload.Scope}
+	if payload.Exp > 0 {
+		info.Expiry = time.Unix(payload.Exp, 0)
+	}
+	return info, nil
+}
+
+// A CachingIntrospector remembers the results of another TokenIntrospector so
+// the OAuth provider is not asked about the same token on every request.
+// Results are kept for TTL or until the token expires, whichever is sooner.
+// Expired results are swept from the cache at most once every TTL when a new
+// result is stored. A CachingIntrospector is safe for concurrent use.
+type CachingIntrospector struct {
+	Introspector TokenIntrospector
+	TTL          time.Duration
+
+	mu        sync.Mutex
+	entries   map[string]cachedTokenInfo
+	nextSweep time.Time
+}
+
+type cachedTokenInfo struct {
+	info    *TokenInfo
+	expires time.Time
+}
+
+// NewCachingIntrospector returns a CachingIntrospector keeping the results of
+// the introspector for ttl.
+func NewCachingIntrospector(introspector TokenIntrospector, ttl time.Duration) *CachingIntrospector {
+	return &CachingIntrospector{
+		Introspector: introspector,
+		TTL:          ttl,
+	}
+}
+
+// Introspect returns the cached result for the token or asks the underlying
+// introspector. Errors are not cached.
+func (c *CachingIntrospector) Introspect(ctx context.Context, token string) (*TokenInfo, error) {
+	now := time.Now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[token]
+	if ok && now.After(entry.expires) {
+		delete(c.entries, token)
+		ok = false
+	}
+	c.mu.Unlock()
+
+	if ok {
+		return entry.info, nil
+	}
+
+	info, err := c.Introspector.Introspect(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	expires := now.Add(c.TTL)
+	if !info.Expiry.IsZero() && info.Expiry.Before(expires) {
+		expires = info.Expiry
+	}
+
+	c.mu.Lock()
+	if c.entries == nil {
+		c.entries = map[string]cachedTokenInfo{}
+	}
+	if now.After(c.nextSweep) {
+		c.sweep(now)
+	}
+	c.entries[token] = cachedTokenInfo{info: info, expires: expires}
+	c.mu.Unlock()
+
+	return info, nil
+}
+
+// sweep removes the entries expired at now. The caller must hold mu.
+func (c *CachingIntrospector) sweep(now time.Time) {
+	for token, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, token)
+		}
+	}
+	c.nextSweep = now.Add(c.TTL)
+}
+
+// An AccountLinker requires customers to link an account before using
+// selected intents. Customers without an active token are sent a
+// LinkAccountCard along with Message instead of reaching the intent handler.
+//
+// https://developer.amazon.com/docs/account-linking/understand-account-linking.html
+type AccountLinker struct {
+	// Introspector validates tokens. When nil any token is accepted.
+	Introspector TokenIntrospector
+	// Message is spoken when an account must be linked.
+	Message string
+	// Timeout bounds each introspection made by RequireLinkedAccount. No
+	// timeout is applied when zero.
+	Timeout time.Duration
+
+	intents map[string]struct{}
+}
+
+// NewAccountLinker returns an AccountLinker requiring a linked account for
+// the named intents. Every intent requires a linked account when none are
+// named.
+func NewAccountLinker(introspector TokenIntrospector, intents ...string) *AccountLinker {
+	l := &AccountLinker{
+		Introspector: introspector,
+		Message:      DefaultLinkAccountMessage,
+		Timeout:      DefaultIntrospectionTimeout,
+		intents:      map[string]struct{}{},
+	}
+	for _, intent := range intents {
+		l.intents[intent] = struct{}{}
+	}
+	return l
+}
+
+// Requires reports whether the intent requires a linked account.
+func (l *AccountLinker) Requires(intent string) bool {
+	if len(l.intents) == 0 {
+		return true
+	}
+	_, ok := l.intents[intent]
+	return ok
+}
+
+// Account returns the linked account of the request. ErrAccountNotLinked is
+// returned when the request has no token or the token is no longer active.
+func (l *AccountLinker) Account(ctx context.Context, req *IntentRequest) (*TokenInfo, error) {
+	token := req.Context.System.User.AccessToken
+	if token == "" {
+		token = req.Session.User.AccessToken
+	}
+	if token == "" {
+		return nil, ErrAccountNotLinked
+	}
+
+	if l.Introspector == nil {
+		return &TokenInfo{Active: true}, nil
+	}
+
+	info, err := l.Introspector.Introspect(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !info.Active {
+		return nil, ErrAccountNotLinked
+	}
+	return info, nil
+}
+
+// RequireLinkedAccount wraps an IntentRequest function of a Handler so the
+// intents of the AccountLinker only reach next when the account is linked.
+// Errors from the Introspector, including an introspection exceeding Timeout,
+// are returned to the Handler.
+func (l *AccountLinker) RequireLinkedAccount(next func(Response, *IntentRequest) error) func(Response, *IntentRequest) error {
+	return func(resp Response, req *IntentRequest) error {
+		if !l.Requires(req.Request.Intent.Name) {
+			return next(resp, req)
+		}
+
+		ctx := context.Background()
+		if l.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, l.Timeout)
+			defer cancel()
+		}
+
+		_, err := l.Account(ctx, req)
+		if err == ErrAccountNotLinked {
+			resp.LinkAccountCard()
+			resp.PlainText(l.Message)
+			resp.ShouldEndSession(true)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		return next(resp, req)
+	}
+}
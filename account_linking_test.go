@@ -0,0 +1,149 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// countingIntrospector reports tokens named "active" as active and counts the
+// tokens it has been asked about.
+type countingIntrospector struct {
+	calls int
+}
+
+func (i *countingIntrospector) Introspect(ctx context.Context, token string) (*TokenInfo, error) {
+	i.calls++
+	return &TokenInfo{Active: token == "active", Subject: "account"}, nil
+}
+
+func TestAccountLinkerRequireLinkedAccount(t *testing.T) {
+	introspector := &countingIntrospector{}
+	linker := NewAccountLinker(NewCachingIntrospector(introspector, time.Minute), "OrderIntent")
+
+	cases := []struct {
+		intent  string
+		token   string
+		reached bool
+	}{
+		{"OrderIntent", "", false},
+		{"OrderIntent", "revoked", false},
+		{"OrderIntent", "active", true},
+		{"OrderIntent", "active", true},
+		{"HelpIntent", "", true},
+	}
+
+	for _, c := range cases {
+		reached := false
+		next := func(Response, *IntentRequest) error {
+			reached = true
+			return nil
+		}
+
+		req := &IntentRequest{}
+		req.Context.System.User.AccessToken = c.token
+		req.Request.Intent.Name = c.intent
+
+		resp := &responseBuilder{Version: version, Response: &response{}}
+		if err := linker.RequireLinkedAccount(next)(resp, req); err != nil {
+			t.Fatalf("Did not want err; got %s", err)
+		}
+
+		if reached != c.reached {
+			t.Errorf("Wanted reached %t for %s with %q; got %t", c.reached, c.intent, c.token, reached)
+		}
+		if linked := resp.Response.Card == nil; linked != c.reached {
+			t.Errorf("Wanted LinkAccount card %t for %s with %q; got %+v", !c.reached, c.intent, c.token, resp.Response.Card)
+		}
+	}
+
+	// The repeated active token is answered from the cache.
+	if introspector.calls != 2 {
+		t.Errorf("Wanted 2 introspections; got %d", introspector.calls)
+	}
+}
+
+func TestAccountLinkerTimeout(t *testing.T) {
+	stalled := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-stalled:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(stalled)
+
+	linker := NewAccountLinker(NewOAuthIntrospector(srv.URL, "id", "secret"))
+	linker.Timeout = 20 * time.Millisecond
+
+	reached := false
+	next := func(Response, *IntentRequest) error {
+		reached = true
+		return nil
+	}
+
+	req := &IntentRequest{}
+	req.Context.System.User.AccessToken = "active"
+
+	start := time.Now()
+	err := linker.RequireLinkedAccount(next)(&responseBuilder{Version: version, Response: &response{}}, req)
+	if err == nil || reached {
+		t.Errorf("Wanted err without reaching next; got %v and reached %t", err, reached)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Wanted introspection to time out; took %s", elapsed)
+	}
+}
+
+func TestCachingIntrospectorEvictsExpired(t *testing.T) {
+	c := NewCachingIntrospector(&countingIntrospector{}, 10*time.Millisecond)
+
+	for _, token := range []string{"first", "second", "third"} {
+		if _, err := c.Introspect(context.Background(), token); err != nil {
+			t.Fatalf("Did not want err; got %s", err)
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	if _, err := c.Introspect(context.Background(), "fourth"); err != nil {
+		t.Fatalf("Did not want err; got %s", err)
+	}
+
+	if _, ok := c.entries["fourth"]; len(c.entries) != 1 || !ok {
+		t.Errorf("Wanted only the fourth token to be cached; got %v", c.entries)
+	}
+}
+
+func TestOAuthIntrospector(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id, secret, ok := r.BasicAuth(); !ok || id != "id" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		r.ParseForm()
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"active": r.Form.Get("token") == "active",
+			"sub":    "account",
+			"scope":  "orders profile",
+			"exp":    2000000000,
+		})
+	}))
+	defer srv.Close()
+
+	info, err := NewOAuthIntrospector(srv.URL, "id", "secret").Introspect(context.Background(), "active")
+	if err != nil {
+		t.Fatalf("Did not want err; got %s", err)
+	}
+	if !info.Active || info.Subject != "account" || !info.HasScope("orders") || info.Expiry.Unix() != 2000000000 {
+		t.Errorf("Unexpected token info %+v", info)
+	}
+
+	_, err = NewOAuthIntrospector(srv.URL, "id", "wrong").Introspect(context.Background(), "active")
+	if serr, ok := err.(*ServiceError); !ok || serr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Wanted 401 *ServiceError; got %v", err)
+	}
+}
@@ -0,0 +1,257 @@
+// Package amazontime parses the values of the AMAZON.DATE and AMAZON.DURATION
+// slot types.
+//
+// Alexa resolves spoken dates such as "next weekend" or "this winter" into
+// values like 2026-W43-WE or 2026-WI. ParseDate turns these into the span of
+// time they refer to relative to a reference time, normally the time of the
+// request in the time zone of the device:
+//
+//	d, err := amazontime.ParseDate(slot.Value, time.Now().In(loc))
+//
+// ParseDuration parses the ISO 8601 durations such as PT10M or P1Y2M used by
+// AMAZON.DURATION.
+package amazontime
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The slot types reported by a ParseError.
+const (
+	DateType     = "AMAZON.DATE"
+	DurationType = "AMAZON.DURATION"
+)
+
+// PresentRef is the date Alexa sends when the customer refers to now.
+const PresentRef = "PRESENT_REF"
+
+// Causes of a ParseError.
+var (
+	ErrUnknownFormat = errors.New("unknown format")
+	ErrOutOfRange    = errors.New("out of range")
+)
+
+// A ParseError is returned when a value cannot be parsed as its slot type.
+type ParseError struct {
+	Type  string
+	Value string
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("amazontime: cannot parse %q as %s: %s", e.Value, e.Type, e.Err)
+}
+
+// A Granularity is the size of the span of time a Date refers to.
+type Granularity int
+
+// The granularities of an AMAZON.DATE value.
+const (
+	Present Granularity = iota
+	Day
+	Weekend
+	Week
+	Month
+	Season
+	Year
+	Decade
+)
+
+var granularityNames = []string{"present", "day", "weekend", "week", "month", "season", "year", "decade"}
+
+func (g Granularity) String() string {
+	if g < 0 || int(g) >= len(granularityNames) {
+		return "Granularity(" + strconv.Itoa(int(g)) + ")"
+	}
+	return granularityNames[g]
+}
+
+// A SeasonName is the season of the year a Date refers to.
+type SeasonName string
+
+// The seasons of an AMAZON.DATE value.
+const (
+	Winter SeasonName = "WI"
+	Spring SeasonName = "SP"
+	Summer SeasonName = "SU"
+	Fall   SeasonName = "FA"
+)
+
+// A Date is the span of time an AMAZON.DATE value refers to. Start is
+// inclusive and End is exclusive so a Day spans from midnight to midnight. A
+// Present date starts and ends at the reference time.
+type Date struct {
+	Value       string
+	Granularity Granularity
+	// Season is set when the Granularity is Season.
+	Season SeasonName
+	Start  time.Time
+	End    time.Time
+}
+
+// Contains reports whether t falls within the date.
+func (d Date) Contains(t time.Time) bool {
+	return !t.Before(d.Start) && t.Before(d.End)
+}
+
+var (
+	decadePattern = regexp.MustCompile(`^(\d{3})X$`)
+	yearPattern   = regexp.MustCompile(`^(\d{4}|XXXX)$`)
+	weekPattern   = regexp.MustCompile(`^W(\d{2})$`)
+	numberPattern = regexp.MustCompile(`^\d{2}$`)
+)
+
+// ParseDate returns the span of time the AMAZON.DATE value refers to. The
+// reference time provides the time zone of the result, the moment of
+// PRESENT_REF and the year of values without one such as XXXX-12-25, which
+// resolve to their next occurrence.
+//
+// Besides days (2026-10-19) values may name a week (2026-W42), the weekend of
+// a week (2026-W42-WE), a month (2026-10), a season (2026-WI), a year (2026)
+// or a decade (201X). Trailing unspecified parts such as the XX of 2026-XX
+// widen the value to the part before them. Seasons follow the meteorological
+// calendar of the northern hemisphere, so the winter of 2026 runs from
+// December 2025 to the end of February 2026.
+func ParseDate(value string, ref time.Time) (Date, error) {
+	d, err := parseDate(value, ref)
+	if err != nil {
+		return Date{}, &ParseError{Type: DateType, Value: value, Err: err}
+	}
+	d.Value = value
+	return d, nil
+}
+
+func parseDate(value string, ref time.Time) (Date, error) {
+	loc := ref.Location()
+
+	if value == PresentRef {
+		return Date{Granularity: Present, Start: ref, End: ref}, nil
+	}
+
+	if m := decadePattern.FindStringSubmatch(value); m != nil {
+		decade, _ := strconv.Atoi(m[1])
+		start := time.Date(decade*10, time.January, 1, 0, 0, 0, 0, loc)
+		return Date{Granularity: Decade, Start: start, End: start.AddDate(10, 0, 0)}, nil
+	}
+
+	parts := strings.Split(value, "-")
+	for len(parts) > 1 && parts[len(parts)-1] == "XX" {
+		parts = parts[:len(parts)-1]
+	}
+
+	if !yearPattern.MatchString(parts[0]) {
+		return Date{}, ErrUnknownFormat
+	}
+	unspecifiedYear := parts[0] == "XXXX"
+	if unspecifiedYear && len(parts) == 1 {
+		return Date{}, ErrUnknownFormat
+	}
+
+	year := ref.Year()
+	if !unspecifiedYear {
+		year, _ = strconv.Atoi(parts[0])
+	}
+
+	d, err := parseDateParts(year, parts[1:], loc)
+	if err != nil {
+		return Date{}, err
+	}
+
+	if unspecifiedYear && !d.End.After(ref) {
+		d, err = parseDateParts(year+1, parts[1:], loc)
+	}
+	return d, err
+}
+
+// parseDateParts returns the date for the parts following the year.
+func parseDateParts(year int, parts []string, loc *time.Location) (Date, error) {
+	if len(parts) == 0 {
+		start := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+		return Date{Granularity: Year, Start: start, End: start.AddDate(1, 0, 0)}, nil
+	}
+
+	switch s := SeasonName(parts[0]); s {
+	case Winter, Spring, Summer, Fall:
+		if len(parts) != 1 {
+			return Date{}, ErrUnknownFormat
+		}
+		return season(year, s, loc), nil
+	}
+
+	if m := weekPattern.FindStringSubmatch(parts[0]); m != nil {
+		week, _ := strconv.Atoi(m[1])
+		if week < 1 || week > weeksInYear(year) {
+			return Date{}, ErrOutOfRange
+		}
+		start := isoWeekStart(year, week, loc)
+
+		switch {
+		case len(parts) == 1:
+			return Date{Granularity: Week, Start: start, End: start.AddDate(0, 0, 7)}, nil
+		case len(parts) == 2 && parts[1] == "WE":
+			start = start.AddDate(0, 0, 5)
+			return Date{Granularity: Weekend, Start: start, End: start.AddDate(0, 0, 2)}, nil
+		}
+		return Date{}, ErrUnknownFormat
+	}
+
+	if !numberPattern.MatchString(parts[0]) || len(parts) > 2 {
+		return Date{}, ErrUnknownFormat
+	}
+	month, _ := strconv.Atoi(parts[0])
+	if month < 1 || month > 12 {
+		return Date{}, ErrOutOfRange
+	}
+
+	if len(parts) == 1 {
+		start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, loc)
+		return Date{Granularity: Month, Start: start, End: start.AddDate(0, 1, 0)}, nil
+	}
+
+	if !numberPattern.MatchString(parts[1]) {
+		return Date{}, ErrUnknownFormat
+	}
+	day, _ := strconv.Atoi(parts[1])
+	start := time.Date(year, time.Month(month), day, 0, 0, 0, 0, loc)
+	if day < 1 || start.Month() != time.Month(month) {
+		return Date{}, ErrOutOfRange
+	}
+	return Date{Granularity: Day, Start: start, End: start.AddDate(0, 0, 1)}, nil
+}
+
+// season returns the three months of the season in the year.
+func season(year int, s SeasonName, loc *time.Location) Date {
+	months := map[SeasonName]time.Month{
+		Winter: time.December,
+		Spring: time.March,
+		Summer: time.June,
+		Fall:   time.September,
+	}
+
+	startYear := year
+	if s == Winter {
+		startYear--
+	}
+	start := time.Date(startYear, months[s], 1, 0, 0, 0, 0, loc)
+	return Date{Granularity: Season, Season: s, Start: start, End: start.AddDate(0, 3, 0)}
+}
+
+// isoWeekStart returns midnight on the Monday of the ISO 8601 week.
+func isoWeekStart(year, week int, loc *time.Location) time.Time {
+	// The fourth of January is always in the first week.
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, loc)
+	offset := (int(jan4.Weekday()) + 6) % 7
+	return jan4.AddDate(0, 0, (week-1)*7-offset)
+}
+
+// weeksInYear returns the number of ISO 8601 weeks in the year.
+func weeksInYear(year int) int {
+	// The twenty eighth of December is always in the last week.
+	_, week := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return week
+}
@@ -0,0 +1,153 @@
+package amazontime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benjic/alexa/amazontime"
+)
+
+var zone = time.FixedZone("PDT", -7*60*60)
+
+// ref is a Monday afternoon in the first days of ISO week 43.
+var ref = time.Date(2026, time.October, 19, 15, 0, 0, 0, zone)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, zone)
+}
+
+func TestParseDate(t *testing.T) {
+	cases := []struct {
+		value       string
+		granularity amazontime.Granularity
+		season      amazontime.SeasonName
+		start, end  time.Time
+	}{
+		// Present
+		{"PRESENT_REF", amazontime.Present, "", ref, ref},
+
+		// Days
+		{"2026-10-19", amazontime.Day, "", day(2026, 10, 19), day(2026, 10, 20)},
+		{"2026-12-31", amazontime.Day, "", day(2026, 12, 31), day(2027, 1, 1)},
+		{"2024-02-29", amazontime.Day, "", day(2024, 2, 29), day(2024, 3, 1)},
+
+		// Weeks
+		{"2026-W42", amazontime.Week, "", day(2026, 10, 12), day(2026, 10, 19)},
+		{"2026-W01", amazontime.Week, "", day(2025, 12, 29), day(2026, 1, 5)},
+		{"2026-W53", amazontime.Week, "", day(2026, 12, 28), day(2027, 1, 4)},
+		{"2021-W01", amazontime.Week, "", day(2021, 1, 4), day(2021, 1, 11)},
+		{"2020-W53", amazontime.Week, "", day(2020, 12, 28), day(2021, 1, 4)},
+
+		// Weekends
+		{"2026-W42-WE", amazontime.Weekend, "", day(2026, 10, 17), day(2026, 10, 19)},
+		{"2026-W01-WE", amazontime.Weekend, "", day(2026, 1, 3), day(2026, 1, 5)},
+
+		// Months
+		{"2026-10", amazontime.Month, "", day(2026, 10, 1), day(2026, 11, 1)},
+		{"2026-12", amazontime.Month, "", day(2026, 12, 1), day(2027, 1, 1)},
+		{"2026-10-XX", amazontime.Month, "", day(2026, 10, 1), day(2026, 11, 1)},
+
+		// Seasons
+		{"2026-WI", amazontime.Season, amazontime.Winter, day(2025, 12, 1), day(2026, 3, 1)},
+		{"2026-SP", amazontime.Season, amazontime.Spring, day(2026, 3, 1), day(2026, 6, 1)},
+		{"2026-SU", amazontime.Season, amazontime.Summer, day(2026, 6, 1), day(2026, 9, 1)},
+		{"2026-FA", amazontime.Season, amazontime.Fall, day(2026, 9, 1), day(2026, 12, 1)},
+
+		// Years
+		{"2026", amazontime.Year, "", day(2026, 1, 1), day(2027, 1, 1)},
+		{"2026-XX", amazontime.Year, "", day(2026, 1, 1), day(2027, 1, 1)},
+		{"2026-XX-XX", amazontime.Year, "", day(2026, 1, 1), day(2027, 1, 1)},
+
+		// Decades
+		{"201X", amazontime.Decade, "", day(2010, 1, 1), day(2020, 1, 1)},
+		{"202X", amazontime.Decade, "", day(2020, 1, 1), day(2030, 1, 1)},
+
+		// Unspecified years resolve to the next occurrence.
+		{"XXXX-12-25", amazontime.Day, "", day(2026, 12, 25), day(2026, 12, 26)},
+		{"XXXX-10-19", amazontime.Day, "", day(2026, 10, 19), day(2026, 10, 20)},
+		{"XXXX-01-01", amazontime.Day, "", day(2027, 1, 1), day(2027, 1, 2)},
+		{"XXXX-10", amazontime.Month, "", day(2026, 10, 1), day(2026, 11, 1)},
+		{"XXXX-09", amazontime.Month, "", day(2027, 9, 1), day(2027, 10, 1)},
+		{"XXXX-W43", amazontime.Week, "", day(2026, 10, 19), day(2026, 10, 26)},
+		{"XXXX-W42", amazontime.Week, "", day(2027, 10, 18), day(2027, 10, 25)},
+		{"XXXX-W42-WE", amazontime.Weekend, "", day(2027, 10, 23), day(2027, 10, 25)},
+		{"XXXX-FA", amazontime.Season, amazontime.Fall, day(2026, 9, 1), day(2026, 12, 1)},
+		{"XXXX-WI", amazontime.Season, amazontime.Winter, day(2026, 12, 1), day(2027, 3, 1)},
+	}
+
+	for _, c := range cases {
+		d, err := amazontime.ParseDate(c.value, ref)
+		if err != nil {
+			t.Errorf("Did not want err for %q; got %s", c.value, err)
+			continue
+		}
+
+		if d.Value != c.value || d.Granularity != c.granularity || d.Season != c.season {
+			t.Errorf("Wanted %s %q for %q; got %s %q", c.granularity, c.season, c.value, d.Granularity, d.Season)
+		}
+		if !d.Start.Equal(c.start) || !d.End.Equal(c.end) {
+			t.Errorf("Wanted %s to %s for %q; got %s to %s", c.start, c.end, c.value, d.Start, d.End)
+		}
+		if d.Start.Location() != zone {
+			t.Errorf("Wanted %s in %s; got %s", c.value, zone, d.Start.Location())
+		}
+	}
+}
+
+func TestParseDateErrors(t *testing.T) {
+	cases := []struct {
+		value string
+		err   error
+	}{
+		{"", amazontime.ErrUnknownFormat},
+		{"tomorrow", amazontime.ErrUnknownFormat},
+		{"XXXX", amazontime.ErrUnknownFormat},
+		{"20XX", amazontime.ErrUnknownFormat},
+		{"26-10-19", amazontime.ErrUnknownFormat},
+		{"2026-1", amazontime.ErrUnknownFormat},
+		{"2026-10-1", amazontime.ErrUnknownFormat},
+		{"2026-10-19-01", amazontime.ErrUnknownFormat},
+		{"2026-W42-XY", amazontime.ErrUnknownFormat},
+		{"2026-WI-01", amazontime.ErrUnknownFormat},
+		{"2026-AU", amazontime.ErrUnknownFormat},
+		{"2026-13", amazontime.ErrOutOfRange},
+		{"2026-00", amazontime.ErrOutOfRange},
+		{"2026-02-29", amazontime.ErrOutOfRange},
+		{"2026-04-31", amazontime.ErrOutOfRange},
+		{"2026-10-00", amazontime.ErrOutOfRange},
+		{"2026-W00", amazontime.ErrOutOfRange},
+		{"2026-W54", amazontime.ErrOutOfRange},
+		{"2025-W53", amazontime.ErrOutOfRange},
+	}
+
+	for _, c := range cases {
+		_, err := amazontime.ParseDate(c.value, ref)
+		perr, ok := err.(*amazontime.ParseError)
+		if !ok || perr.Err != c.err || perr.Type != amazontime.DateType || perr.Value != c.value {
+			t.Errorf("Wanted %s for %q; got %v", c.err, c.value, err)
+		}
+	}
+}
+
+func TestDateContains(t *testing.T) {
+	d, err := amazontime.ParseDate("2026-W42-WE", ref)
+	if err != nil {
+		t.Fatalf("Did not want err; got %s", err)
+	}
+
+	cases := []struct {
+		t    time.Time
+		want bool
+	}{
+		{day(2026, 10, 16), false},
+		{day(2026, 10, 17), true},
+		{day(2026, 10, 18).Add(23 * time.Hour), true},
+		{day(2026, 10, 19), false},
+	}
+
+	for _, c := range cases {
+		if got := d.Contains(c.t); got != c.want {
+			t.Errorf("Wanted %t for %s; got %t", c.want, c.t, got)
+		}
+	}
+}
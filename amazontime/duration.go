@@ -0,0 +1,102 @@
+package amazontime
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Duration is an AMAZON.DURATION value. Each component is kept as given
+// since years, months and days vary in length.
+type Duration struct {
+	Years   int
+	Months  int
+	Weeks   int
+	Days    int
+	Hours   int
+	Minutes int
+	Seconds float64
+}
+
+var durationPattern = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// ParseDuration parses an ISO 8601 duration such as PT10M, P2D or
+// P1Y2M3DT4H5M6.5S.
+func ParseDuration(value string) (Duration, error) {
+	m := durationPattern.FindStringSubmatch(value)
+	if m == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return Duration{}, &ParseError{Type: DurationType, Value: value, Err: ErrUnknownFormat}
+	}
+
+	var d Duration
+	for i, field := range []*int{&d.Years, &d.Months, &d.Weeks, &d.Days, &d.Hours, &d.Minutes} {
+		if m[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return Duration{}, &ParseError{Type: DurationType, Value: value, Err: ErrOutOfRange}
+		}
+		*field = n
+	}
+	if m[7] != "" {
+		d.Seconds, _ = strconv.ParseFloat(m[7], 64)
+	}
+	return d, nil
+}
+
+// AddTo returns t after the duration has passed, following the calendar of
+// the location of t for years, months, weeks and days.
+func (d Duration) AddTo(t time.Time) time.Time {
+	return t.AddDate(d.Years, d.Months, d.Weeks*7+d.Days).Add(d.clock())
+}
+
+// Approximate returns the duration as a time.Duration, counting years as 365
+// days and months as 30 days.
+func (d Duration) Approximate() time.Duration {
+	day := 24 * time.Hour
+	days := time.Duration(d.Years*365+d.Months*30+d.Weeks*7+d.Days) * day
+	return days + d.clock()
+}
+
+// clock returns the hours, minutes and seconds of the duration.
+func (d Duration) clock() time.Duration {
+	return time.Duration(d.Hours)*time.Hour +
+		time.Duration(d.Minutes)*time.Minute +
+		time.Duration(d.Seconds*float64(time.Second))
+}
+
+// String returns the duration in ISO 8601 form.
+func (d Duration) String() string {
+	var b strings.Builder
+	b.WriteString("P")
+
+	dateParts := []struct {
+		n    int
+		unit string
+	}{{d.Years, "Y"}, {d.Months, "M"}, {d.Weeks, "W"}, {d.Days, "D"}}
+	for _, p := range dateParts {
+		if p.n != 0 {
+			b.WriteString(strconv.Itoa(p.n) + p.unit)
+		}
+	}
+
+	if d.Hours != 0 || d.Minutes != 0 || d.Seconds != 0 {
+		b.WriteString("T")
+		if d.Hours != 0 {
+			b.WriteString(strconv.Itoa(d.Hours) + "H")
+		}
+		if d.Minutes != 0 {
+			b.WriteString(strconv.Itoa(d.Minutes) + "M")
+		}
+		if d.Seconds != 0 {
+			b.WriteString(strconv.FormatFloat(d.Seconds, 'f', -1, 64) + "S")
+		}
+	}
+
+	if b.Len() == 1 {
+		return "PT0S"
+	}
+	return b.String()
+}
@@ -0,0 +1,74 @@
+package amazontime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benjic/alexa/amazontime"
+)
+
+func TestParseDuration(t *testing.T) {
+	cases := []struct {
+		value  string
+		want   amazontime.Duration
+		approx time.Duration
+		string string
+	}{
+		{"PT10M", amazontime.Duration{Minutes: 10}, 10 * time.Minute, "PT10M"},
+		{"PT1H30M", amazontime.Duration{Hours: 1, Minutes: 30}, 90 * time.Minute, "PT1H30M"},
+		{"PT45S", amazontime.Duration{Seconds: 45}, 45 * time.Second, "PT45S"},
+		{"PT0.5S", amazontime.Duration{Seconds: 0.5}, 500 * time.Millisecond, "PT0.5S"},
+		{"P2D", amazontime.Duration{Days: 2}, 48 * time.Hour, "P2D"},
+		{"P1W", amazontime.Duration{Weeks: 1}, 7 * 24 * time.Hour, "P1W"},
+		{"P3M", amazontime.Duration{Months: 3}, 90 * 24 * time.Hour, "P3M"},
+		{"P1Y", amazontime.Duration{Years: 1}, 365 * 24 * time.Hour, "P1Y"},
+		{"P1DT12H", amazontime.Duration{Days: 1, Hours: 12}, 36 * time.Hour, "P1DT12H"},
+		{
+			"P1Y2M3DT4H5M6.5S",
+			amazontime.Duration{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6.5},
+			428*24*time.Hour + 4*time.Hour + 5*time.Minute + 6500*time.Millisecond,
+			"P1Y2M3DT4H5M6.5S",
+		},
+		{"P0D", amazontime.Duration{}, 0, "PT0S"},
+	}
+
+	for _, c := range cases {
+		d, err := amazontime.ParseDuration(c.value)
+		if err != nil {
+			t.Errorf("Did not want err for %q; got %s", c.value, err)
+			continue
+		}
+		if d != c.want {
+			t.Errorf("Wanted %+v for %q; got %+v", c.want, c.value, d)
+		}
+		if got := d.Approximate(); got != c.approx {
+			t.Errorf("Wanted approximately %s for %q; got %s", c.approx, c.value, got)
+		}
+		if got := d.String(); got != c.string {
+			t.Errorf("Wanted %s for %q; got %s", c.string, c.value, got)
+		}
+	}
+}
+
+func TestParseDurationErrors(t *testing.T) {
+	for _, value := range []string{"", "P", "PT", "10M", "PT10", "P1H", "PT1D", "P1.5D", "-PT10M"} {
+		_, err := amazontime.ParseDuration(value)
+		perr, ok := err.(*amazontime.ParseError)
+		if !ok || perr.Type != amazontime.DurationType {
+			t.Errorf("Wanted *ParseError for %q; got %v", value, err)
+		}
+	}
+}
+
+func TestDurationAddTo(t *testing.T) {
+	d, err := amazontime.ParseDuration("P1Y2M3DT4H5M6.5S")
+	if err != nil {
+		t.Fatalf("Did not want err; got %s", err)
+	}
+
+	start := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	want := time.Date(2027, time.March, 4, 4, 5, 6, 500000000, time.UTC)
+	if got := d.AddTo(start); !got.Equal(want) {
+		t.Errorf("Wanted %s; got %s", want, got)
+	}
+}
@@ -0,0 +1,101 @@
+package alexa
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	accountProfilePath = "/v2/accounts/~current/settings/Profile."
+	personProfilePath  = "/v2/persons/~current/profile/"
+)
+
+// A Person is a speaker Alexa has recognized by their voice.
+type Person struct {
+	ID          string `json:"personId"`
+	AccessToken string `json:"accessToken"`
+}
+
+// Recognized reports whether Alexa recognized the speaker.
+func (p Person) Recognized() bool {
+	return p.ID != ""
+}
+
+// A MobileNumber is a phone number a customer has added to their profile.
+type MobileNumber struct {
+	CountryCode string `json:"countryCode"`
+	PhoneNumber string `json:"phoneNumber"`
+}
+
+// A CustomerProfileClient reads the contact information of a customer with the
+// Customer Profile API. The account methods read the profile of the account
+// owner while the person methods read the profile of the recognized speaker.
+//
+// https://developer.amazon.com/docs/custom-skills/request-customer-contact-information-for-use-in-your-skill.html
+type CustomerProfileClient struct {
+	*ServiceClient
+}
+
+// NewCustomerProfileClient returns a CustomerProfileClient for the apiEndpoint
+// and apiAccessToken provided in the context of a request.
+func NewCustomerProfileClient(apiEndpoint, apiAccessToken string) *CustomerProfileClient {
+	return &CustomerProfileClient{NewServiceClient(apiEndpoint, apiAccessToken)}
+}
+
+// Name returns the full name of the account owner.
+func (c *CustomerProfileClient) Name(ctx context.Context) (string, error) {
+	return c.getString(ctx, accountProfilePath+"name", NamePermission)
+}
+
+// GivenName returns the given name of the account owner.
+func (c *CustomerProfileClient) GivenName(ctx context.Context) (string, error) {
+	return c.getString(ctx, accountProfilePath+"givenName", GivenNamePermission)
+}
+
+// Email returns the email address of the account owner.
+func (c *CustomerProfileClient) Email(ctx context.Context) (string, error) {
+	return c.getString(ctx, accountProfilePath+"email", EmailPermission)
+}
+
+// MobileNumber returns the mobile number of the account owner.
+func (c *CustomerProfileClient) MobileNumber(ctx context.Context) (*MobileNumber, error) {
+	n := &MobileNumber{}
+	if err := c.get(ctx, accountProfilePath+"mobileNumber", n, MobileNumberPermission); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// PersonName returns the full name of the recognized speaker.
+func (c *CustomerProfileClient) PersonName(ctx context.Context) (string, error) {
+	return c.getString(ctx, personProfilePath+"name", NamePermission)
+}
+
+// PersonGivenName returns the given name of the recognized speaker.
+func (c *CustomerProfileClient) PersonGivenName(ctx context.Context) (string, error) {
+	return c.getString(ctx, personProfilePath+"givenName", GivenNamePermission)
+}
+
+// PersonMobileNumber returns the mobile number of the recognized speaker.
+func (c *CustomerProfileClient) PersonMobileNumber(ctx context.Context) (*MobileNumber, error) {
+	n := &MobileNumber{}
+	if err := c.get(ctx, personProfilePath+"mobileNumber", n, MobileNumberPermission); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// getString reads the profile field at path as a string.
+func (c *CustomerProfileClient) getString(ctx context.Context, path string, p Permission) (string, error) {
+	var s string
+	if err := c.get(ctx, path, &s, p); err != nil {
+		return "", err
+	}
+	return s, nil
+}
+
+// get reads the profile field at path into out. A *PermissionError is returned
+// when the customer has not granted the permission for the field.
+func (c *CustomerProfileClient) get(ctx context.Context, path string, out interface{}, p Permission) error {
+	return permissionError(c.Do(ctx, http.MethodGet, path, nil, out), p)
+}
@@ -0,0 +1,47 @@
+package alexa_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/benjic/alexa"
+)
+
+func TestCustomerProfileClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/accounts/~current/settings/Profile.givenName":
+			w.Write([]byte(`"Jane"`))
+		case "/v2/accounts/~current/settings/Profile.mobileNumber":
+			w.Write([]byte(`{"countryCode": "+1", "phoneNumber": "2065550100"}`))
+		case "/v2/persons/~current/profile/givenName":
+			w.Write([]byte(`"John"`))
+		case "/v2/accounts/~current/settings/Profile.email":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			t.Errorf("Unexpected request for %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := alexa.NewCustomerProfileClient(srv.URL, "token")
+	ctx := context.Background()
+
+	if name, err := c.GivenName(ctx); err != nil || name != "Jane" {
+		t.Errorf("Wanted Jane; got %q, %v", name, err)
+	}
+	if name, err := c.PersonGivenName(ctx); err != nil || name != "John" {
+		t.Errorf("Wanted John; got %q, %v", name, err)
+	}
+	if n, err := c.MobileNumber(ctx); err != nil || *n != (alexa.MobileNumber{CountryCode: "+1", PhoneNumber: "2065550100"}) {
+		t.Errorf("Unexpected mobile number %+v, %v", n, err)
+	}
+
+	_, err := c.Email(ctx)
+	if perr, ok := err.(*alexa.PermissionError); !ok || perr.Permissions[0] != alexa.EmailPermission {
+		t.Errorf("Wanted *PermissionError for %s; got %v", alexa.EmailPermission, err)
+	}
+}
@@ -0,0 +1,36 @@
+package alexa
+
+// A Device describes the device a request was made from.
+type Device struct {
+	ID                  string              `json:"deviceId"`
+	SupportedInterfaces SupportedInterfaces `json:"supportedInterfaces"`
+}
+
+// SupportedInterfaces lists the interfaces a device is capable of handling. An
+// interface is supported when its field is non nil.
+type SupportedInterfaces struct {
+	AudioPlayer *struct{} `json:"AudioPlayer,omitempty"`
+	Display     *struct {
+		TemplateVersion string `json:"templateVersion"`
+		MarkupVersion   string `json:"markupVersion"`
+	} `json:"Display,omitempty"`
+	VideoApp *struct{} `json:"VideoApp,omitempty"`
+}
+
+// SupportsAudio reports whether the device can play audio with the AudioPlayer
+// interface.
+func (d Device) SupportsAudio() bool {
+	return d.SupportedInterfaces.AudioPlayer != nil
+}
+
+// SupportsDisplay reports whether the device has a screen that can render
+// display templates.
+func (d Device) SupportsDisplay() bool {
+	return d.SupportedInterfaces.Display != nil
+}
+
+// SupportsVideo reports whether the device can play video with the VideoApp
+// interface.
+func (d Device) SupportsVideo() bool {
+	return d.SupportedInterfaces.VideoApp != nil
+}
@@ -0,0 +1,61 @@
+package alexa
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// An Address is the address a customer has set for a device.
+type Address struct {
+	AddressLine1     string `json:"addressLine1"`
+	AddressLine2     string `json:"addressLine2"`
+	AddressLine3     string `json:"addressLine3"`
+	City             string `json:"city"`
+	CountryCode      string `json:"countryCode"`
+	DistrictOrCounty string `json:"districtOrCounty"`
+	PostalCode       string `json:"postalCode"`
+	StateOrRegion    string `json:"stateOrRegion"`
+}
+
+// A DeviceAddressClient reads the address of a device with the Device Address
+// API.
+//
+// https://developer.amazon.com/docs/custom-skills/device-address-api.html
+type DeviceAddressClient struct {
+	*ServiceClient
+}
+
+// NewDeviceAddressClient returns a DeviceAddressClient for the apiEndpoint and
+// apiAccessToken provided in the context of a request.
+func NewDeviceAddressClient(apiEndpoint, apiAccessToken string) *DeviceAddressClient {
+	return &DeviceAddressClient{NewServiceClient(apiEndpoint, apiAccessToken)}
+}
+
+// FullAddress returns the full address of the device. A *PermissionError is
+// returned when the customer has not granted the FullAddressPermission.
+func (c *DeviceAddressClient) FullAddress(ctx context.Context, deviceID string) (*Address, error) {
+	a := &Address{}
+	path := "/v1/devices/" + url.PathEscape(deviceID) + "/settings/address"
+	if err := c.get(ctx, path, a, FullAddressPermission); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// CountryAndPostalCode returns an Address with only the country code and
+// postal code of the device. A *PermissionError is returned when the customer
+// has not granted the CountryAndPostalCodePermission.
+func (c *DeviceAddressClient) CountryAndPostalCode(ctx context.Context, deviceID string) (*Address, error) {
+	a := &Address{}
+	path := "/v1/devices/" + url.PathEscape(deviceID) + "/settings/address/countryAndPostalCode"
+	if err := c.get(ctx, path, a, CountryAndPostalCodePermission); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// get reads the resource at path into out.
+func (c *DeviceAddressClient) get(ctx context.Context, path string, out interface{}, p Permission) error {
+	return permissionError(c.Do(ctx, http.MethodGet, path, nil, out), p)
+}
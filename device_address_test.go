@@ -0,0 +1,53 @@
+package alexa_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/benjic/alexa"
+)
+
+func TestDeviceAddressClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/devices/device-id/settings/address":
+			w.Write([]byte(`{
+				"stateOrRegion": "WA",
+				"city": "Seattle",
+				"countryCode": "US",
+				"postalCode": "98109",
+				"addressLine1": "410 Terry Ave North",
+				"addressLine2": "",
+				"addressLine3": "aeiou",
+				"districtOrCounty": ""
+			}`))
+		case "/v1/devices/device-id/settings/address/countryAndPostalCode":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			t.Errorf("Unexpected request for %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := alexa.NewDeviceAddressClient(srv.URL, "token")
+
+	a, err := c.FullAddress(context.Background(), "device-id")
+	if err != nil {
+		t.Fatalf("Did not want err; got %s", err)
+	}
+	if a.City != "Seattle" || a.PostalCode != "98109" || a.AddressLine1 != "410 Terry Ave North" {
+		t.Errorf("Unexpected address %+v", a)
+	}
+
+	_, err = c.CountryAndPostalCode(context.Background(), "device-id")
+	perr, ok := err.(*alexa.PermissionError)
+	if !ok {
+		t.Fatalf("Wanted *PermissionError; got %T %v", err, err)
+	}
+	if want := []alexa.Permission{alexa.CountryAndPostalCodePermission}; !reflect.DeepEqual(perr.Permissions, want) {
+		t.Errorf("Wanted %v; got %v", want, perr.Permissions)
+	}
+}
@@ -0,0 +1,128 @@
+package alexa
+
+import (
+	"errors"
+	"strings"
+)
+
+const dynamicAuthorityPrefix = "amzn1.er-authority.echo-sdk.dynamic"
+
+// A ResolutionStatus is the outcome of resolving a slot value against the
+// values of an authority.
+type ResolutionStatus string
+
+// The outcomes of entity resolution.
+const (
+	MatchResolutionStatus     ResolutionStatus = "ER_SUCCESS_MATCH"
+	NoMatchResolutionStatus   ResolutionStatus = "ER_SUCCESS_NO_MATCH"
+	TimeoutResolutionStatus   ResolutionStatus = "ER_ERROR_TIMEOUT"
+	ExceptionResolutionStatus ResolutionStatus = "ER_ERROR_EXCEPTION"
+)
+
+// Errors returned when a slot value could not be resolved to an entity.
+var (
+	ErrEntityNoMatch   = errors.New("entity resolution: no match")
+	ErrEntityTimeout   = errors.New("entity resolution: timed out")
+	ErrEntityException = errors.New("entity resolution: exception")
+)
+
+// Resolutions holds the results of resolving a slot value against the custom
+// slot type and any dynamic entities of the skill.
+//
+// https://developer.amazon.com/docs/custom-skills/entity-resolution.html
+type Resolutions struct {
+	ResolutionsPerAuthority []Resolution `json:"resolutionsPerAuthority"`
+}
+
+// A Resolution is the result of resolving a slot value against a single
+// authority.
+type Resolution struct {
+	Authority string `json:"authority"`
+	Status    struct {
+		Code ResolutionStatus `json:"code"`
+	} `json:"status"`
+	Values []struct {
+		Value ResolvedValue `json:"value"`
+	} `json:"values"`
+}
+
+// Dynamic reports whether the authority holds the dynamic entities of the
+// skill rather than the values of the slot type.
+func (r Resolution) Dynamic() bool {
+	return strings.HasPrefix(r.Authority, dynamicAuthorityPrefix)
+}
+
+// A ResolvedValue is a canonical value of a slot type.
+type ResolvedValue struct {
+	Name string `json:"name"`
+	ID   string `json:"id"`
+}
+
+// An EntityMatch is a canonical value a slot value resolved to along with
+// the authority that matched it.
+type EntityMatch struct {
+	ResolvedValue
+	Authority string
+	Dynamic   bool
+}
+
+// Matches returns every canonical value the slot resolved to. Dynamic entities
+// are returned before static values and each authority keeps the order
+// provided by Alexa. A slot accepting multiple values has no matches of its
+// own; each of its Values must be resolved instead.
+func (s Slot) Matches() []EntityMatch {
+	v, err := s.value()
+	if err != nil {
+		return []EntityMatch{}
+	}
+	return v.Resolutions.matches()
+}
+
+// Match returns the best canonical value for the slot, preferring dynamic
+// entities to static values. When nothing matched the error is
+// ErrEntityTimeout or ErrEntityException if an authority failed and
+// ErrEntityNoMatch otherwise. ErrSlotMultipleValues is returned for a slot
+// accepting multiple values.
+func (s Slot) Match() (EntityMatch, error) {
+	v, err := s.value()
+	if err != nil {
+		return EntityMatch{}, err
+	}
+	return v.Resolutions.match()
+}
+
+func (rs Resolutions) matches() []EntityMatch {
+	ms := []EntityMatch{}
+	for _, dynamic := range []bool{true, false} {
+		for _, r := range rs.ResolutionsPerAuthority {
+			if r.Dynamic() != dynamic || r.Status.Code != MatchResolutionStatus {
+				continue
+			}
+			for _, v := range r.Values {
+				ms = append(ms, EntityMatch{
+					ResolvedValue: v.Value,
+					Authority:     r.Authority,
+					Dynamic:       dynamic,
+				})
+			}
+		}
+	}
+	return ms
+}
+
+func (rs Resolutions) match() (EntityMatch, error) {
+	if ms := rs.matches(); len(ms) > 0 {
+		return ms[0], nil
+	}
+
+	err := ErrEntityNoMatch
+	for _, r := range rs.ResolutionsPerAuthority {
+		switch r.Status.Code {
+		case TimeoutResolutionStatus:
+			return EntityMatch{}, ErrEntityTimeout
+		case ExceptionResolutionStatus:
+			err = ErrEntityException
+		}
+	}
+	return EntityMatch{}, err
+}
@@ -0,0 +1,127 @@
+package alexa_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/benjic/alexa"
+)
+
+const (
+	staticAuthority  = "amzn1.er-authority.echo-sdk.amzn1.ask.skill.TEST.Drink"
+	dynamicAuthority = "amzn1.er-authority.echo-sdk.dynamic.amzn1.ask.skill.TEST.Drink"
+)
+
+func resolvedSlot(t *testing.T, resolutions string) alexa.Slot {
+	t.Helper()
+
+	s := alexa.Slot{Name: "drink", Value: "latte"}
+	if err := json.Unmarshal([]byte(resolutions), &s.Resolutions); err != nil {
+		t.Fatalf("failed to unmarshal resolutions: %s", err)
+	}
+	return s
+}
+
+func TestSlotMatch(t *testing.T) {
+	s := resolvedSlot(t, `{
+		"resolutionsPerAuthority": [
+			{
+				"authority": "`+staticAuthority+`",
+				"status": {"code": "ER_SUCCESS_MATCH"},
+				"values": [
+					{"value": {"name": "caffe latte", "id": "LATTE"}},
+					{"value": {"name": "latte macchiato", "id": "MACCHIATO"}}
+				]
+			},
+			{
+				"authority": "`+dynamicAuthority+`",
+				"status": {"code": "ER_SUCCESS_MATCH"},
+				"values": [
+					{"value": {"name": "pumpkin spice latte", "id": "SEASONAL"}}
+				]
+			}
+		]
+	}`)
+
+	m, err := s.Match()
+	if err != nil {
+		t.Fatalf("Did not want err; got %s", err)
+	}
+	if m.ID != "SEASONAL" || !m.Dynamic || m.Authority != dynamicAuthority {
+		t.Errorf("Wanted dynamic SEASONAL match; got %+v", m)
+	}
+
+	ms := s.Matches()
+	if len(ms) != 3 || ms[1].ID != "LATTE" || ms[1].Dynamic || ms[2].ID != "MACCHIATO" {
+		t.Errorf("Unexpected matches %+v", ms)
+	}
+}
+
+func TestSlotMatchErrors(t *testing.T) {
+	cases := []struct {
+		resolutions string
+		err         error
+	}{
+		{`{}`, alexa.ErrEntityNoMatch},
+		{`{
+			"resolutionsPerAuthority": [
+				{"authority": "` + staticAuthority + `", "status": {"code": "ER_SUCCESS_NO_MATCH"}}
+			]
+		}`, alexa.ErrEntityNoMatch},
+		{`{
+			"resolutionsPerAuthority": [
+				{"authority": "` + staticAuthority + `", "status": {"code": "ER_SUCCESS_NO_MATCH"}},
+				{"authority": "` + dynamicAuthority + `", "status": {"code": "ER_ERROR_TIMEOUT"}}
+			]
+		}`, alexa.ErrEntityTimeout},
+		{`{
+			"resolutionsPerAuthority": [
+				{"authority": "` + staticAuthority + `", "status": {"code": "ER_ERROR_EXCEPTION"}}
+			]
+		}`, alexa.ErrEntityException},
+	}
+
+	for _, c := range cases {
+		if _, err := resolvedSlot(t, c.resolutions).Match(); err != c.err {
+			t.Errorf("Wanted %s for %s; got %v", c.err, c.resolutions, err)
+		}
+	}
+
+	if _, err := (alexa.Slot{Name: "drink"}).Match(); err != alexa.ErrSlotNotFilled {
+		t.Errorf("Wanted %s; got %v", alexa.ErrSlotNotFilled, err)
+	}
+}
+
+func TestSlotValueMatch(t *testing.T) {
+	match := resolvedSlot(t, `{
+		"resolutionsPerAuthority": [
+			{
+				"authority": "`+staticAuthority+`",
+				"status": {"code": "ER_SUCCESS_MATCH"},
+				"values": [{"value": {"name": "caffe latte", "id": "LATTE"}}]
+			}
+		]
+	}`).Resolutions
+
+	simple := alexa.Slot{Name: "drink", SlotValue: &alexa.SlotValue{Type: alexa.SimpleSlotValueType, Value: "latte", Resolutions: match}}
+	if m, err := simple.Match(); err != nil || m.ID != "LATTE" {
+		t.Errorf("Wanted LATTE match from the simple slotValue; got %+v, %v", m, err)
+	}
+	if ms := simple.Matches(); len(ms) != 1 || ms[0].ID != "LATTE" {
+		t.Errorf("Wanted LATTE matches from the simple slotValue; got %+v", ms)
+	}
+
+	list := alexa.Slot{Name: "drinks", SlotValue: &alexa.SlotValue{
+		Type:   alexa.ListSlotValueType,
+		Values: []alexa.SlotValue{{Type: alexa.SimpleSlotValueType, Value: "latte", Resolutions: match}},
+	}}
+	if _, err := list.Match(); err != alexa.ErrSlotMultipleValues {
+		t.Errorf("Wanted %s; got %v", alexa.ErrSlotMultipleValues, err)
+	}
+	if ms := list.Matches(); len(ms) != 0 {
+		t.Errorf("Did not want matches for a list slot; got %+v", ms)
+	}
+	if m, err := list.Values()[0].Match(); err != nil || m.ID != "LATTE" {
+		t.Errorf("Wanted LATTE match from the list value; got %+v, %v", m, err)
+	}
+}
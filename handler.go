@@ -1,14 +1,15 @@
 // Package alexa provides a way to write typed handlers for requests made from
 // the Alexa Skill service.
-//
-//
 package alexa
 
 import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"log"
 	"net/http"
+
+	"github.com/benjic/alexa/i18n"
 )
 
 type body struct {
@@ -17,11 +18,13 @@ type body struct {
 			Application struct {
 				ApplicationID string `json:"applicationId"`
 			} `json:"application"`
+			Device Device `json:"device"`
 		} `json:"system"`
 	} `json:"context"`
 	Request struct {
-		Type      string `json:"type"`
-		Timestamp string `json:"timestamp"`
+		Type      RequestType `json:"type"`
+		Timestamp string      `json:"timestamp"`
+		Locale    string      `json:"locale"`
 	}
 	bs []byte
 }
@@ -35,6 +38,10 @@ type Handler struct {
 	LaunchRequest       func(Response, *LaunchRequest) error
 	SessionEndedRequest SessionEndedRequestHandler
 
+	// In-Skill Purchasing Handlers
+
+	ConnectionsResponseRequest ConnectionsResponseHandler
+
 	// Audio Request Handlers
 
 	AudioPlaybackFailedRequest         AudioPlaybackFailedHandler
@@ -46,11 +53,46 @@ type Handler struct {
 	// Playback Controller Handlers
 
 	PlaybackControllerNextCommandRequest     PlaybackControllerRequestHandler
-	PlaybackControllerPausedCommandRequest   PlaybackControllerRequestHandler
+	PlaybackControllerPauseCommandRequest    PlaybackControllerRequestHandler
 	PlaybackControllerPlayCommandRequest     PlaybackControllerRequestHandler
 	PlaybackControllerPreviousCommandRequest PlaybackControllerRequestHandler
 
 	SystemExceptionRequest SystemExceptionEncounteredHandler
+
+	// Household List Event Handlers
+
+	ListItemsCreatedRequest ListItemsEventHandler
+	ListItemsDeletedRequest ListItemsEventHandler
+	ListItemsUpdatedRequest ListItemsEventHandler
+
+	// Skill Messaging Handlers
+
+	MessageReceivedRequest MessageReceivedHandler
+
+	// Reminder Event Handlers
+
+	ReminderCreatedRequest       ReminderEventHandler
+	ReminderDeletedRequest       ReminderEventHandler
+	ReminderStartedRequest       ReminderEventHandler
+	ReminderStatusChangedRequest ReminderEventHandler
+	ReminderUpdatedRequest       ReminderEventHandler
+
+	// Catalog provides the messages spoken by Response.Speak in the locale
+	// of each request.
+	Catalog *i18n.Catalog
+
+	// Validation selects whether responses are checked against the limits
+	// of Alexa before they are sent.
+	Validation ValidationMode
+
+	// OnInvalidResponse is called with the *ValidationError of every
+	// response found to be invalid, before the response is sent or replaced
+	// by an internal server error according to Validation.
+	OnInvalidResponse func(err error)
+
+	// ErrorLog receives problems found with responses. The standard logger
+	// is used when nil.
+	ErrorLog *log.Logger
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -61,7 +103,7 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := verifyRequest(r, body); err != nil {
+	if err := verify(r, body); err != nil {
 		// Invalid requests are dropped immediately.
 		return
 	}
@@ -74,122 +116,298 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	if resp != nil {
 		// Any non nil response should be written.
-		w.Header().Add("Content-Type", "application/json;charset=UTF-8")
-		w.WriteHeader(http.StatusOK)
-
-		// TODO(benjic): Handle json error
-		json.NewEncoder(w).Encode(resp)
+		h.writeResponse(w, resp)
 	}
 }
 
-func (h *Handler) routeRequest(b *body) (Response, error) {
-	resp := &responseBuilder{Version: version, Response: &response{}}
-
-	switch b.Request.Type {
-	case launchRequestType:
-		if h.LaunchRequest != nil {
-			req := &LaunchRequest{}
-			if err := json.Unmarshal(b.bs, req); err != nil {
-				return resp, err
+// writeResponse encodes the response and checks it according to the
+// Validation mode before writing it.
+func (h *Handler) writeResponse(w http.ResponseWriter, resp Response) {
+	bs, err := json.Marshal(resp)
+	if err != nil {
+		h.logf("alexa: failed to encode response: %s", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if b, ok := resp.(*responseBuilder); ok && h.Validation != NoValidation {
+		if err := b.validate(len(bs)); err != nil {
+			if h.OnInvalidResponse != nil {
+				h.OnInvalidResponse(err)
 			}
-			return resp, h.LaunchRequest(resp, req)
-		}
-	case intentRequestType:
-		if h.IntentRequest != nil {
-			req := &IntentRequest{}
-			if err := json.Unmarshal(b.bs, req); err != nil {
-				return nil, err
+			h.logf("%s", err)
+			if h.Validation == StrictValidation {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
 			}
-			return resp, h.IntentRequest(resp, req)
 		}
-	case sessionEndedRequestType:
-		if h.SessionEndedRequest != nil {
-			req := &SessionEndedRequest{}
-			if err := json.Unmarshal(b.bs, req); err != nil {
-				return nil, err
-			}
-			return nil, h.SessionEndedRequest(req)
+	}
+
+	w.Header().Add("Content-Type", "application/json;charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(bs)
+}
+
+// A route decodes a request of a single type and invokes the matching Handler
+// function if one is set. The returned Response is nil when Alexa does not
+// accept a response for the request type.
+type route func(h *Handler, resp *responseBuilder, bs []byte) (Response, error)
+
+// routes associates every known request type with its route.
+var routes = map[RequestType]route{
+	LaunchRequestType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.LaunchRequest == nil {
+			return resp, nil
 		}
-	case audioPlayerPlaybackFailedType:
-		if h.AudioPlaybackFailedRequest != nil {
-			req := &AudioPlaybackFailedRequest{}
-			if err := json.Unmarshal(b.bs, req); err != nil {
-				return nil, err
-			}
-			return nil, h.AudioPlaybackFailedRequest(resp, req)
+		req := &LaunchRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
 		}
-	case audioPlayerPlaybackStartedType:
-		if h.AudioPlaybackStartedRequest != nil {
-			req := &AudioPlaybackRequest{}
-			if err := json.Unmarshal(b.bs, req); err != nil {
-				return nil, err
-			}
-			return resp, h.AudioPlaybackStartedRequest(resp, req)
+		return resp, h.LaunchRequest(resp, req)
+	},
+	IntentRequestType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.IntentRequest == nil {
+			return resp, nil
 		}
-	case audioPlayerPlaybackStoppedType:
-		if h.AudioPlaybackStoppedRequest != nil {
-			req := &AudioPlaybackRequest{}
-			if err := json.Unmarshal(b.bs, req); err != nil {
-				return nil, err
-			}
-			return resp, h.AudioPlaybackStoppedRequest(req)
+		req := &IntentRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
 		}
-	case audioPlayerPlaybackFinishedType:
-		if h.AudioPlaybackFinishedRequest != nil {
-			req := &AudioPlaybackRequest{}
-			if err := json.Unmarshal(b.bs, req); err != nil {
-				return nil, err
-			}
-			return resp, h.AudioPlaybackFinishedRequest(resp, req)
+		return resp, h.IntentRequest(resp, req)
+	},
+	SessionEndedRequestType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.SessionEndedRequest == nil {
+			return nil, nil
 		}
-	case audioPlayerPlaybackNearlyFinishedType:
-		if h.AudioPlaybackNearlyFinishedRequest != nil {
-			req := &AudioPlaybackRequest{}
-			if err := json.Unmarshal(b.bs, req); err != nil {
-				return nil, err
-			}
-			return resp, h.AudioPlaybackNearlyFinishedRequest(resp, req)
+		req := &SessionEndedRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
 		}
-	case playbackControllerNextCommandIssuedType:
-		if h.PlaybackControllerNextCommandRequest != nil {
-			req := &PlaybackControllerRequest{}
-			if err := json.Unmarshal(b.bs, req); err != nil {
-				return nil, err
-			}
-			return nil, h.PlaybackControllerNextCommandRequest(resp, req)
+		return nil, h.SessionEndedRequest(req)
+	},
+	AudioPlayerPlaybackFailedType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.AudioPlaybackFailedRequest == nil {
+			return resp, nil
 		}
-	case playbackControllerPlayCommandIssuedType:
-		if h.PlaybackControllerPlayCommandRequest != nil {
-			req := &PlaybackControllerRequest{}
-			if err := json.Unmarshal(b.bs, req); err != nil {
-				return nil, err
-			}
-			return nil, h.PlaybackControllerPlayCommandRequest(resp, req)
+		req := &AudioPlaybackFailedRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
 		}
-	case playbackControllerPausedCommandIssuedType:
-		if h.PlaybackControllerPausedCommandRequest != nil {
-			req := &PlaybackControllerRequest{}
-			if err := json.Unmarshal(b.bs, req); err != nil {
-				return nil, err
-			}
-			return nil, h.PlaybackControllerPausedCommandRequest(resp, req)
+		return resp, h.AudioPlaybackFailedRequest(resp, req)
+	},
+	AudioPlayerPlaybackStartedType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.AudioPlaybackStartedRequest == nil {
+			return resp, nil
 		}
-	case playbackControllerPreviousCommandIssuedType:
-		if h.PlaybackControllerPreviousCommandRequest != nil {
-			req := &PlaybackControllerRequest{}
-			if err := json.Unmarshal(b.bs, req); err != nil {
-				return nil, err
-			}
-			return nil, h.PlaybackControllerPreviousCommandRequest(resp, req)
+		req := &AudioPlaybackRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
 		}
-	case systemExceptionEncounteredType:
-		if h.SystemExceptionRequest != nil {
-			req := &SystemExceptionEncounteredRequest{}
-			if err := json.Unmarshal(b.bs, req); err != nil {
-				return nil, err
-			}
-			return nil, h.SystemExceptionRequest(req)
+		return resp, h.AudioPlaybackStartedRequest(resp, req)
+	},
+	AudioPlayerPlaybackStoppedType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.AudioPlaybackStoppedRequest == nil {
+			return nil, nil
+		}
+		req := &AudioPlaybackRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
+		}
+		return nil, h.AudioPlaybackStoppedRequest(req)
+	},
+	AudioPlayerPlaybackFinishedType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.AudioPlaybackFinishedRequest == nil {
+			return resp, nil
+		}
+		req := &AudioPlaybackRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
+		}
+		return resp, h.AudioPlaybackFinishedRequest(resp, req)
+	},
+	AudioPlayerPlaybackNearlyFinishedType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.AudioPlaybackNearlyFinishedRequest == nil {
+			return resp, nil
 		}
+		req := &AudioPlaybackRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
+		}
+		return resp, h.AudioPlaybackNearlyFinishedRequest(resp, req)
+	},
+	PlaybackControllerNextCommandIssuedType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.PlaybackControllerNextCommandRequest == nil {
+			return resp, nil
+		}
+		req := &PlaybackControllerRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
+		}
+		return resp, h.PlaybackControllerNextCommandRequest(resp, req)
+	},
+	PlaybackControllerPauseCommandIssuedType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.PlaybackControllerPauseCommandRequest == nil {
+			return resp, nil
+		}
+		req := &PlaybackControllerRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
+		}
+		return resp, h.PlaybackControllerPauseCommandRequest(resp, req)
+	},
+	PlaybackControllerPlayCommandIssuedType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.PlaybackControllerPlayCommandRequest == nil {
+			return resp, nil
+		}
+		req := &PlaybackControllerRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
+		}
+		return resp, h.PlaybackControllerPlayCommandRequest(resp, req)
+	},
+	PlaybackControllerPreviousCommandIssuedType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.PlaybackControllerPreviousCommandRequest == nil {
+			return resp, nil
+		}
+		req := &PlaybackControllerRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
+		}
+		return resp, h.PlaybackControllerPreviousCommandRequest(resp, req)
+	},
+	SystemExceptionEncounteredType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.SystemExceptionRequest == nil {
+			return nil, nil
+		}
+		req := &SystemExceptionEncounteredRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
+		}
+		return nil, h.SystemExceptionRequest(req)
+	},
+	RemindersReminderCreatedType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.ReminderCreatedRequest == nil {
+			return nil, nil
+		}
+		req := &ReminderEventRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
+		}
+		return nil, h.ReminderCreatedRequest(req)
+	},
+	RemindersReminderDeletedType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.ReminderDeletedRequest == nil {
+			return nil, nil
+		}
+		req := &ReminderEventRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
+		}
+		return nil, h.ReminderDeletedRequest(req)
+	},
+	RemindersReminderStartedType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.ReminderStartedRequest == nil {
+			return nil, nil
+		}
+		req := &ReminderEventRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
+		}
+		return nil, h.ReminderStartedRequest(req)
+	},
+	RemindersReminderStatusChangedType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.ReminderStatusChangedRequest == nil {
+			return nil, nil
+		}
+		req := &ReminderEventRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
+		}
+		return nil, h.ReminderStatusChangedRequest(req)
+	},
+	RemindersReminderUpdatedType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.ReminderUpdatedRequest == nil {
+			return nil, nil
+		}
+		req := &ReminderEventRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
+		}
+		return nil, h.ReminderUpdatedRequest(req)
+	},
+	HouseholdListItemsCreatedType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.ListItemsCreatedRequest == nil {
+			return nil, nil
+		}
+		req := &ListItemsEventRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
+		}
+		return nil, h.ListItemsCreatedRequest(req)
+	},
+	HouseholdListItemsDeletedType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.ListItemsDeletedRequest == nil {
+			return nil, nil
+		}
+		req := &ListItemsEventRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
+		}
+		return nil, h.ListItemsDeletedRequest(req)
+	},
+	HouseholdListItemsUpdatedType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.ListItemsUpdatedRequest == nil {
+			return nil, nil
+		}
+		req := &ListItemsEventRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
+		}
+		return nil, h.ListItemsUpdatedRequest(req)
+	},
+	MessagingMessageReceivedType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.MessageReceivedRequest == nil {
+			return nil, nil
+		}
+		req := &MessageReceivedRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
+		}
+		return nil, h.MessageReceivedRequest(req)
+	},
+	ConnectionsResponseType: func(h *Handler, resp *responseBuilder, bs []byte) (Response, error) {
+		if h.ConnectionsResponseRequest == nil {
+			return resp, nil
+		}
+		req := &ConnectionsResponseRequest{}
+		if err := json.Unmarshal(bs, req); err != nil {
+			return nil, err
+		}
+		return resp, h.ConnectionsResponseRequest(resp, req)
+	},
+}
+
+// logf writes to the ErrorLog of the handler.
+func (h *Handler) logf(format string, args ...interface{}) {
+	if h.ErrorLog != nil {
+		h.ErrorLog.Printf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
+
+func (h *Handler) routeRequest(b *body) (Response, error) {
+	resp := &responseBuilder{
+		Version:     version,
+		Response:    &response{},
+		device:      b.Context.System.Device,
+		requestType: b.Request.Type,
+		locale:      b.Request.Locale,
+		catalog:     h.Catalog,
+	}
+
+	if r, ok := routes[b.Request.Type]; ok {
+		return r(h, resp, b.bs)
 	}
 	return resp, nil
 }
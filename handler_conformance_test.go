@@ -0,0 +1,488 @@
+package alexa
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// conformanceFixture wraps a request object in the envelope Alexa sends with
+// every request.
+func conformanceFixture(request string) string {
+	return fmt.Sprintf(`{
+		"version": "1.0",
+		"session": {
+			"new": true,
+			"sessionId": "amzn1.echo-api.session.0000000-0000-0000-0000-00000000000",
+			"application": {
+				"applicationId": "amzn1.ask.skill.00000000-0000-0000-0000-000000000000"
+			},
+			"attributes": {},
+			"user": {
+				"userId": "amzn1.ask.account.AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"
+			}
+		},
+		"context": {
+			"System": {
+				"application": {
+					"applicationId": "amzn1.ask.skill.00000000-0000-0000-0000-000000000000"
+				},
+				"user": {
+					"userId": "amzn1.ask.account.AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"
+				},
+				"device": {
+					"deviceId": "amzn1.ask.device.AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+					"supportedInterfaces": {
+						"AudioPlayer": {}
+					}
+				},
+				"apiEndpoint": "https://api.amazonalexa.com"
+			},
+			"AudioPlayer": {
+				"token": "track-1",
+				"offsetInMilliseconds": 1000,
+				"playerActivity": "PLAYING"
+			}
+		},
+		"request": %s
+	}`, request)
+}
+
+// conformanceFixtures holds a canonical request object for every request type
+// documented by Amazon.
+var conformanceFixtures = map[RequestType]string{
+	AudioPlayerPlaybackFailedType: `{
+		"type": "AudioPlayer.PlaybackFailed",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"locale": "en-US",
+		"token": "track-1",
+		"error": {
+			"type": "MEDIA_ERROR_INTERNAL_DEVICE_ERROR",
+			"message": "Internal device error"
+		},
+		"currentPlaybackState": {
+			"token": "track-1",
+			"offsetInMilliseconds": 1000,
+			"playerActivity": "PLAYING"
+		}
+	}`,
+	AudioPlayerPlaybackFinishedType: `{
+		"type": "AudioPlayer.PlaybackFinished",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"locale": "en-US",
+		"token": "track-1",
+		"offsetInMilliseconds": 1000
+	}`,
+	AudioPlayerPlaybackNearlyFinishedType: `{
+		"type": "AudioPlayer.PlaybackNearlyFinished",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"locale": "en-US",
+		"token": "track-1",
+		"offsetInMilliseconds": 1000
+	}`,
+	AudioPlayerPlaybackStartedType: `{
+		"type": "AudioPlayer.PlaybackStarted",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"locale": "en-US",
+		"token": "track-1",
+		"offsetInMilliseconds": 0
+	}`,
+	AudioPlayerPlaybackStoppedType: `{
+		"type": "AudioPlayer.PlaybackStopped",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"locale": "en-US",
+		"token": "track-1",
+		"offsetInMilliseconds": 1000
+	}`,
+	ConnectionsResponseType: `{
+		"type": "Connections.Response",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"locale": "en-US",
+		"name": "Buy",
+		"status": {
+			"code": "200",
+			"message": "OK"
+		},
+		"payload": {
+			"purchaseResult": "ACCEPTED",
+			"productId": "amzn1.adg.product.0000000-0000-0000-0000-00000000000"
+		},
+		"token": "correlation-token"
+	}`,
+	HouseholdListItemsCreatedType: `{
+		"type": "AlexaHouseholdListEvent.ItemsCreated",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"locale": "en-US",
+		"body": {
+			"listId": "list-id",
+			"listItemIds": ["item-id"]
+		}
+	}`,
+	HouseholdListItemsDeletedType: `{
+		"type": "AlexaHouseholdListEvent.ItemsDeleted",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"locale": "en-US",
+		"body": {
+			"listId": "list-id",
+			"listItemIds": ["item-id"]
+		}
+	}`,
+	HouseholdListItemsUpdatedType: `{
+		"type": "AlexaHouseholdListEvent.ItemsUpdated",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"locale": "en-US",
+		"body": {
+			"listId": "list-id",
+			"listItemIds": ["item-id"]
+		}
+	}`,
+	IntentRequestType: `{
+		"type": "IntentRequest",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"dialogState": "COMPLETED",
+		"locale": "en-US",
+		"intent": {
+			"name": "GetZodiacHoroscopeIntent",
+			"confirmationStatus": "NONE",
+			"slots": {
+				"ZodiacSign": {
+					"name": "ZodiacSign",
+					"value": "virgo",
+					"confirmationStatus": "NONE"
+				}
+			}
+		}
+	}`,
+	LaunchRequestType: `{
+		"type": "LaunchRequest",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"locale": "en-US"
+	}`,
+	MessagingMessageReceivedType: `{
+		"type": "Messaging.MessageReceived",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"locale": "en-US",
+		"message": {
+			"score": 42
+		}
+	}`,
+	PlaybackControllerNextCommandIssuedType: `{
+		"type": "PlaybackController.NextCommandIssued",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"locale": "en-US"
+	}`,
+	PlaybackControllerPauseCommandIssuedType: `{
+		"type": "PlaybackController.PauseCommandIssued",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"locale": "en-US"
+	}`,
+	PlaybackControllerPlayCommandIssuedType: `{
+		"type": "PlaybackController.PlayCommandIssued",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"locale": "en-US"
+	}`,
+	PlaybackControllerPreviousCommandIssuedType: `{
+		"type": "PlaybackController.PreviousCommandIssued",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"locale": "en-US"
+	}`,
+	RemindersReminderCreatedType: `{
+		"type": "Reminders.ReminderCreated",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"locale": "en-US",
+		"body": {
+			"alertToken": "alert-token"
+		}
+	}`,
+	RemindersReminderDeletedType: `{
+		"type": "Reminders.ReminderDeleted",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"locale": "en-US",
+		"body": {
+			"alertTokens": ["alert-token"]
+		}
+	}`,
+	RemindersReminderStartedType: `{
+		"type": "Reminders.ReminderStarted",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"locale": "en-US",
+		"body": {
+			"alertToken": "alert-token"
+		}
+	}`,
+	RemindersReminderStatusChangedType: `{
+		"type": "Reminders.ReminderStatusChanged",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"locale": "en-US",
+		"body": {
+			"alertToken": "alert-token",
+			"status": "COMPLETED"
+		}
+	}`,
+	RemindersReminderUpdatedType: `{
+		"type": "Reminders.ReminderUpdated",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"locale": "en-US",
+		"body": {
+			"alertToken": "alert-token"
+		}
+	}`,
+	SessionEndedRequestType: `{
+		"type": "SessionEndedRequest",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"reason": "USER_INITIATED",
+		"locale": "en-US"
+	}`,
+	SystemExceptionEncounteredType: `{
+		"type": "System.ExceptionEncountered",
+		"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000",
+		"timestamp": "2017-12-23T12:34:56Z",
+		"locale": "en-US",
+		"error": {
+			"type": "INVALID_RESPONSE",
+			"message": "Invalid response"
+		},
+		"cause": {
+			"requestId": "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000"
+		}
+	}`,
+}
+
+// A conformanceCall records a handler function invocation and the request it
+// decoded.
+type conformanceCall struct {
+	Type    RequestType
+	Request interface{}
+}
+
+// conformanceHandler returns a Handler with every function set. Each function
+// records the request type it was written for and its request in called.
+func conformanceHandler(called *[]conformanceCall) *Handler {
+	record := func(t RequestType, req interface{}) error {
+		*called = append(*called, conformanceCall{t, req})
+		return nil
+	}
+
+	return &Handler{
+		ConnectionsResponseRequest: func(_ Response, req *ConnectionsResponseRequest) error {
+			return record(ConnectionsResponseType, req)
+		},
+		IntentRequest: func(_ Response, req *IntentRequest) error {
+			return record(IntentRequestType, req)
+		},
+		LaunchRequest: func(_ Response, req *LaunchRequest) error {
+			return record(LaunchRequestType, req)
+		},
+		SessionEndedRequest: func(req *SessionEndedRequest) error {
+			return record(SessionEndedRequestType, req)
+		},
+		AudioPlaybackFailedRequest: func(_ AudioPlayerStopperQueueClearer, req *AudioPlaybackFailedRequest) error {
+			return record(AudioPlayerPlaybackFailedType, req)
+		},
+		AudioPlaybackFinishedRequest: func(_ AudioStopperQueueClearer, req *AudioPlaybackRequest) error {
+			return record(AudioPlayerPlaybackFinishedType, req)
+		},
+		AudioPlaybackNearlyFinishedRequest: func(_ AudioPlayerStopperQueueClearer, req *AudioPlaybackRequest) error {
+			return record(AudioPlayerPlaybackNearlyFinishedType, req)
+		},
+		AudioPlaybackStartedRequest: func(_ AudioStopperQueueClearer, req *AudioPlaybackRequest) error {
+			return record(AudioPlayerPlaybackStartedType, req)
+		},
+		AudioPlaybackStoppedRequest: func(req *AudioPlaybackRequest) error {
+			return record(AudioPlayerPlaybackStoppedType, req)
+		},
+		PlaybackControllerNextCommandRequest: func(_ AudioPlayerStopperQueueClearer, req *PlaybackControllerRequest) error {
+			return record(PlaybackControllerNextCommandIssuedType, req)
+		},
+		PlaybackControllerPauseCommandRequest: func(_ AudioPlayerStopperQueueClearer, req *PlaybackControllerRequest) error {
+			return record(PlaybackControllerPauseCommandIssuedType, req)
+		},
+		PlaybackControllerPlayCommandRequest: func(_ AudioPlayerStopperQueueClearer, req *PlaybackControllerRequest) error {
+			return record(PlaybackControllerPlayCommandIssuedType, req)
+		},
+		PlaybackControllerPreviousCommandRequest: func(_ AudioPlayerStopperQueueClearer, req *PlaybackControllerRequest) error {
+			return record(PlaybackControllerPreviousCommandIssuedType, req)
+		},
+		SystemExceptionRequest: func(req *SystemExceptionEncounteredRequest) error {
+			return record(SystemExceptionEncounteredType, req)
+		},
+		ListItemsCreatedRequest: func(req *ListItemsEventRequest) error {
+			return record(HouseholdListItemsCreatedType, req)
+		},
+		ListItemsDeletedRequest: func(req *ListItemsEventRequest) error {
+			return record(HouseholdListItemsDeletedType, req)
+		},
+		ListItemsUpdatedRequest: func(req *ListItemsEventRequest) error {
+			return record(HouseholdListItemsUpdatedType, req)
+		},
+		MessageReceivedRequest: func(req *MessageReceivedRequest) error {
+			return record(MessagingMessageReceivedType, req)
+		},
+		ReminderCreatedRequest: func(req *ReminderEventRequest) error {
+			return record(RemindersReminderCreatedType, req)
+		},
+		ReminderDeletedRequest: func(req *ReminderEventRequest) error {
+			return record(RemindersReminderDeletedType, req)
+		},
+		ReminderStartedRequest: func(req *ReminderEventRequest) error {
+			return record(RemindersReminderStartedType, req)
+		},
+		ReminderStatusChangedRequest: func(req *ReminderEventRequest) error {
+			return record(RemindersReminderStatusChangedType, req)
+		},
+		ReminderUpdatedRequest: func(req *ReminderEventRequest) error {
+			return record(RemindersReminderUpdatedType, req)
+		},
+	}
+}
+
+func TestConformanceFixtures(t *testing.T) {
+	for _, rt := range KnownRequestTypes() {
+		if _, ok := conformanceFixtures[rt]; !ok {
+			t.Errorf("Missing conformance fixture for %s", rt)
+		}
+	}
+
+	for rt := range conformanceFixtures {
+		if !rt.Known() {
+			t.Errorf("Fixture provided for unknown request type %s", rt)
+		}
+	}
+}
+
+// checkConformanceFields asserts that fields from the fixture for each request
+// type survive decoding into the request passed to the handler.
+func checkConformanceFields(t *testing.T, req interface{}) {
+	t.Helper()
+
+	want := func(field string, got, expected interface{}) {
+		t.Helper()
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("Wanted %s to be %v; got %v", field, expected, got)
+		}
+	}
+
+	switch req := req.(type) {
+	case *AudioPlaybackFailedRequest:
+		want("locale", req.Request.Locale, "en-US")
+		want("error type", req.Request.Error.Type, "MEDIA_ERROR_INTERNAL_DEVICE_ERROR")
+		want("current playback state", req.Request.CurrentPlaybackState, PlaybackState{Token: "track-1", OffsetInMilliseconds: 1000, PlayerActivity: "PLAYING"})
+		want("context token", req.Context.AudioPlayer.Token, "track-1")
+	case *AudioPlaybackRequest:
+		want("locale", req.Request.Locale, "en-US")
+		want("token", req.Request.Token, "track-1")
+		want("context offset", req.Context.AudioPlayer.OffsetInMilliseconds, 1000)
+	case *ConnectionsResponseRequest:
+		want("locale", req.Request.Locale, "en-US")
+		want("name", req.Request.Name, "Buy")
+		want("status code", req.Request.Status.Code, "200")
+		want("purchase result", req.Request.Payload.PurchaseResult, AcceptedPurchaseResult)
+	case *IntentRequest:
+		want("locale", req.Request.Locale, "en-US")
+		want("dialog state", req.Request.DialogState, "COMPLETED")
+		want("intent name", req.Request.Intent.Name, "GetZodiacHoroscopeIntent")
+		want("slot value", req.Request.Intent.Slots["ZodiacSign"].Value, "virgo")
+		want("session new", req.Session.New, true)
+	case *LaunchRequest:
+		want("locale", req.Request.Locale, "en-US")
+		want("api endpoint", req.Context.System.APIEndpoint, "https://api.amazonalexa.com")
+	case *ListItemsEventRequest:
+		want("locale", req.Request.Locale, "en-US")
+		want("list id", req.Request.Body.ListID, "list-id")
+		want("list item ids", req.Request.Body.ListItemIDs, []string{"item-id"})
+	case *MessageReceivedRequest:
+		want("locale", req.Request.Locale, "en-US")
+		want("message", req.Request.Message["score"], float64(42))
+	case *PlaybackControllerRequest:
+		want("locale", req.Request.Locale, "en-US")
+		want("context activity", req.Context.AudioPlayer.PlayerActivity, "PLAYING")
+	case *ReminderEventRequest:
+		want("locale", req.Request.Locale, "en-US")
+		if req.Request.Body.AlertToken != "alert-token" && !reflect.DeepEqual(req.Request.Body.AlertTokens, []string{"alert-token"}) {
+			t.Errorf("Wanted alert token alert-token; got %+v", req.Request.Body)
+		}
+	case *SessionEndedRequest:
+		want("locale", req.Request.Locale, "en-US")
+		want("reason", req.Request.Reason, "USER_INITIATED")
+	case *SystemExceptionEncounteredRequest:
+		want("locale", req.Request.Locale, "en-US")
+		want("error type", req.Request.Error.Type, "INVALID_RESPONSE")
+		want("cause request id", req.Request.Cause.RequestID, "amzn1.echo-api.request.0000000-0000-0000-0000-00000000000")
+	default:
+		t.Errorf("No field checks for request %T", req)
+	}
+}
+
+func TestConformance(t *testing.T) {
+	defer func(v func(*http.Request, *body) error) { verify = v }(verify)
+	verify = func(*http.Request, *body) error { return nil }
+
+	for rt, request := range conformanceFixtures {
+		t.Run(string(rt), func(t *testing.T) {
+			var called []conformanceCall
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(conformanceFixture(request)))
+			conformanceHandler(&called).ServeHTTP(w, r)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Wanted status %d; got %d", http.StatusOK, w.Code)
+			}
+
+			if len(called) != 1 || called[0].Type != rt {
+				t.Fatalf("Wanted %s handler to be called once; got %v", rt, called)
+			}
+
+			checkConformanceFields(t, called[0].Request)
+		})
+	}
+}
+
+func TestConformanceUnsetHandler(t *testing.T) {
+	for rt, request := range conformanceFixtures {
+		b, err := parseRequestBody(strings.NewReader(conformanceFixture(request)))
+		if err != nil {
+			t.Fatalf("Failed to parse fixture for %s: %s", rt, err)
+		}
+
+		if _, err := (&Handler{}).routeRequest(b); err != nil {
+			t.Errorf("Did not want err for %s; got %s", rt, err)
+		}
+	}
+}
+
+func TestConnectionsResponsePurchaseResult(t *testing.T) {
+	req := &ConnectionsResponseRequest{}
+	if err := json.Unmarshal([]byte(conformanceFixture(conformanceFixtures[ConnectionsResponseType])), req); err != nil {
+		t.Fatalf("Did not want err; got %s", err)
+	}
+
+	if req.Request.Payload.PurchaseResult != AcceptedPurchaseResult || req.Request.Name != "Buy" || req.Request.Token != "correlation-token" {
+		t.Errorf("Unexpected request %+v", req.Request)
+	}
+}
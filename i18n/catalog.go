@@ -0,0 +1,348 @@
+// Package i18n resolves the messages a skill speaks in the locale of each
+// request.
+//
+// A Catalog holds the messages of every locale, normally loaded from one file
+// per locale named after it such as en-GB.json or de-DE.yaml:
+//
+//	//go:embed messages
+//	var messages embed.FS
+//
+//	c := i18n.NewCatalog("en-US")
+//	if err := c.LoadFS(messages, "messages"); err != nil {
+//		log.Fatal(err)
+//	}
+//	text, err := c.Lookup("en-GB", "items", i18n.Args{"count": 3})
+//
+// Each file maps message keys to either a single text, a list of variants
+// chosen at random, or a map of plural forms selected by the count argument:
+//
+//	{
+//		"greeting": "Hello {name}!",
+//		"welcome": ["Welcome back!", "Good to see you again!"],
+//		"items": {"one": "You have {count} item.", "other": "You have {count} items."}
+//	}
+//
+// Messages missing from a locale such as en-GB are looked up in its language
+// en and then in the default locale of the Catalog.
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math/rand"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/benjic/alexa/ssml"
+)
+
+// CountArg is the argument selecting the plural form of a message.
+const CountArg = "count"
+
+// Args are the values substituted for the {name} variables of a message.
+type Args map[string]interface{}
+
+// A MissingError is returned when a message is not found in a locale or any
+// of its fallbacks.
+type MissingError struct {
+	Locale string
+	Key    string
+}
+
+func (e *MissingError) Error() string {
+	return fmt.Sprintf("i18n: no message %q for locale %s", e.Key, e.Locale)
+}
+
+// A message is the variants of each plural form of a message. Messages without
+// plural forms only have the other form.
+type message map[PluralForm][]string
+
+// A Catalog holds the messages of every locale. A Catalog is safe for
+// concurrent use once loaded.
+type Catalog struct {
+	// DefaultLocale is searched when a message is missing from the locale
+	// of a request and its language.
+	DefaultLocale string
+	// Intn chooses between the variants of a message. It defaults to
+	// rand.Intn.
+	Intn func(n int) int
+
+	mu       sync.RWMutex
+	messages map[string]map[string]message
+}
+
+// NewCatalog returns an empty Catalog falling back to the defaultLocale.
+func NewCatalog(defaultLocale string) *Catalog {
+	return &Catalog{
+		DefaultLocale: defaultLocale,
+		Intn:          rand.Intn,
+	}
+}
+
+// AddJSON adds the messages of the JSON document to the locale.
+func (c *Catalog) AddJSON(locale string, data []byte) error {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("i18n: %s: %s", locale, err)
+	}
+	return c.add(locale, doc)
+}
+
+// AddYAML adds the messages of the YAML document to the locale. Only the
+// subset of YAML needed by a catalog is understood: mappings of keys to plain
+// or quoted strings, lists of strings and mappings of plural forms, along
+// with comments.
+func (c *Catalog) AddYAML(locale string, data []byte) error {
+	doc, err := parseYAML(string(data))
+	if err != nil {
+		return fmt.Errorf("i18n: %s: %s", locale, err)
+	}
+	return c.add(locale, doc)
+}
+
+// LoadFS adds every .json, .yaml and .yml file in the directory of fsys, such
+// as an embed.FS. The name of each file without its extension is the locale
+// of its messages.
+func (c *Catalog) LoadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		name := e.Name()
+		ext := path.Ext(name)
+		var add func(string, []byte) error
+		switch ext {
+		case ".json":
+			add = c.AddJSON
+		case ".yaml", ".yml":
+			add = c.AddYAML
+		default:
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		if err := add(strings.TrimSuffix(name, ext), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadDir adds every message file in the directory of the file system like
+// LoadFS.
+func (c *Catalog) LoadDir(dir string) error {
+	return c.LoadFS(os.DirFS(dir), ".")
+}
+
+// add converts a decoded document into messages of the locale.
+func (c *Catalog) add(locale string, doc map[string]interface{}) error {
+	msgs := map[string]message{}
+	for key, v := range doc {
+		m, err := newMessage(v)
+		if err != nil {
+			return fmt.Errorf("i18n: %s: %s: %s", locale, key, err)
+		}
+		msgs[key] = m
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.messages == nil {
+		c.messages = map[string]map[string]message{}
+	}
+	if c.messages[locale] == nil {
+		c.messages[locale] = map[string]message{}
+	}
+	for key, m := range msgs {
+		c.messages[locale][key] = m
+	}
+	return nil
+}
+
+// newMessage converts a decoded value into a message.
+func newMessage(v interface{}) (message, error) {
+	if forms, ok := v.(map[string]interface{}); ok {
+		m := message{}
+		for form, fv := range forms {
+			if !PluralForm(form).valid() {
+				return nil, fmt.Errorf("unknown plural form %q", form)
+			}
+			variants, err := newVariants(fv)
+			if err != nil {
+				return nil, err
+			}
+			m[PluralForm(form)] = variants
+		}
+		if _, ok := m[Other]; !ok {
+			return nil, fmt.Errorf("missing plural form %q", Other)
+		}
+		return m, nil
+	}
+
+	variants, err := newVariants(v)
+	if err != nil {
+		return nil, err
+	}
+	return message{Other: variants}, nil
+}
+
+// newVariants converts a decoded string or list of strings into variants.
+func newVariants(v interface{}) ([]string, error) {
+	switch v := v.(type) {
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		if len(v) == 0 {
+			return nil, fmt.Errorf("no variants")
+		}
+		variants := make([]string, 0, len(v))
+		for _, s := range v {
+			str, ok := s.(string)
+			if !ok {
+				return nil, fmt.Errorf("variant %v is not a string", s)
+			}
+			variants = append(variants, str)
+		}
+		return variants, nil
+	}
+	return nil, fmt.Errorf("%v is not a string, list or plural forms", v)
+}
+
+// Fallbacks returns the locales searched for a message of the locale in
+// order, such as en-GB, en and then the default locale.
+func (c *Catalog) Fallbacks(locale string) []string {
+	var locales []string
+	seen := map[string]bool{}
+	add := func(l string) {
+		if l != "" && !seen[l] {
+			seen[l] = true
+			locales = append(locales, l)
+		}
+	}
+
+	add(locale)
+	add(language(locale))
+	add(c.DefaultLocale)
+	add(language(c.DefaultLocale))
+	return locales
+}
+
+// Lookup returns the message for the key in the locale with the variables
+// substituted by the args. When the message has plural forms the count
+// argument selects between them. Arguments substituted into an SSML message,
+// one starting with <speak>, are escaped. A *MissingError is returned when no
+// fallback of the locale has the message.
+func (c *Catalog) Lookup(locale, key string, args Args) (string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, l := range c.Fallbacks(locale) {
+		m, ok := c.messages[l][key]
+		if !ok {
+			continue
+		}
+
+		variants := m[Other]
+		if n, ok := count(args); ok {
+			if vs, ok := m[Plural(l, n)]; ok {
+				variants = vs
+			}
+		}
+
+		text := variants[0]
+		if len(variants) > 1 {
+			intn := c.Intn
+			if intn == nil {
+				intn = rand.Intn
+			}
+			text = variants[intn(len(variants))]
+		}
+		return substitute(text, args), nil
+	}
+
+	return "", &MissingError{Locale: locale, Key: key}
+}
+
+// Text returns the message like Lookup or the key when it is missing.
+func (c *Catalog) Text(locale, key string, args Args) string {
+	text, err := c.Lookup(locale, key, args)
+	if err != nil {
+		return key
+	}
+	return text
+}
+
+// language returns the language of the locale, such as en for en-GB.
+func language(locale string) string {
+	if i := strings.IndexAny(locale, "-_"); i >= 0 {
+		return locale[:i]
+	}
+	return locale
+}
+
+// count returns the integer count argument.
+func count(args Args) (int, bool) {
+	switch n := args[CountArg].(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case int32:
+		return int(n), true
+	case uint:
+		return int(n), true
+	case float64:
+		if n == float64(int(n)) {
+			return int(n), true
+		}
+	}
+	return 0, false
+}
+
+// substitute replaces each {name} in the text with the argument of the name.
+// Variables without an argument are left in place. Arguments are escaped when
+// the text is SSML.
+func substitute(text string, args Args) string {
+	if len(args) == 0 || !strings.Contains(text, "{") {
+		return text
+	}
+	isSSML := strings.HasPrefix(text, "<speak>")
+
+	var b strings.Builder
+	for {
+		open := strings.IndexByte(text, '{')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(text[open:], '}')
+		if end < 0 {
+			break
+		}
+		end += open
+
+		b.WriteString(text[:open])
+		if v, ok := args[text[open+1:end]]; ok && isSSML {
+			b.WriteString(ssml.Escape(fmt.Sprint(v)))
+		} else if ok {
+			fmt.Fprint(&b, v)
+		} else {
+			b.WriteString(text[open : end+1])
+		}
+		text = text[end+1:]
+	}
+	b.WriteString(text)
+	return b.String()
+}
@@ -0,0 +1,172 @@
+package i18n_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/benjic/alexa/i18n"
+)
+
+var messages = fstest.MapFS{
+	"messages/en.json": {Data: []byte(`{
+		"greeting": "Hello {name}!",
+		"farewell": "Goodbye.",
+		"order": "<speak>One {item}, coming up.</speak>",
+		"welcome": ["Welcome back!", "Good to see you again!"],
+		"items": {"one": "You have {count} item.", "other": "You have {count} items."}
+	}`)},
+	"messages/en-GB.yaml": {Data: []byte(`
+# Only messages that differ from en are needed.
+farewell: Cheerio.
+`)},
+	"messages/fr-FR.yml": {Data: []byte(`
+greeting: "Bonjour {name} !"
+items:
+  one: Vous avez {count} article.
+  other: Vous avez {count} articles.
+`)},
+	"messages/README.md": {Data: []byte("ignored")},
+}
+
+func newCatalog(t *testing.T) *i18n.Catalog {
+	t.Helper()
+
+	c := i18n.NewCatalog("en-US")
+	if err := c.LoadFS(messages, "messages"); err != nil {
+		t.Fatalf("failed to load catalog: %s", err)
+	}
+	return c
+}
+
+func TestCatalogLookup(t *testing.T) {
+	cat := newCatalog(t)
+	cat.Intn = func(n int) int { return n - 1 }
+
+	cases := []struct {
+		locale string
+		key    string
+		args   i18n.Args
+		want   string
+	}{
+		{"en-US", "greeting", i18n.Args{"name": "Sam"}, "Hello Sam!"},
+		{"en-GB", "greeting", i18n.Args{"name": "Sam"}, "Hello Sam!"},
+		{"en-GB", "farewell", nil, "Cheerio."},
+		{"en-AU", "farewell", nil, "Goodbye."},
+		{"de-DE", "farewell", nil, "Goodbye."},
+		{"fr-FR", "greeting", i18n.Args{"name": "Sam"}, "Bonjour Sam !"},
+		{"fr-CA", "greeting", i18n.Args{"name": "Sam"}, "Hello Sam!"},
+		{"en-US", "greeting", nil, "Hello {name}!"},
+		{"en-US", "greeting", i18n.Args{"name": "Sam & <Al>"}, "Hello Sam & <Al>!"},
+		{"en-US", "order", i18n.Args{"item": "fish & chips <large>"}, "<speak>One fish &amp; chips &lt;large&gt;, coming up.</speak>"},
+		{"en-US", "welcome", nil, "Good to see you again!"},
+		{"en-US", "items", i18n.Args{"count": 1}, "You have 1 item."},
+		{"en-US", "items", i18n.Args{"count": 0}, "You have 0 items."},
+		{"en-US", "items", i18n.Args{"count": 2.0}, "You have 2 items."},
+		{"en-US", "items", nil, "You have {count} items."},
+		{"fr-FR", "items", i18n.Args{"count": 0}, "Vous avez 0 article."},
+		{"fr-FR", "items", i18n.Args{"count": 3}, "Vous avez 3 articles."},
+	}
+
+	for _, c := range cases {
+		got, err := cat.Lookup(c.locale, c.key, c.args)
+		if err != nil || got != c.want {
+			t.Errorf("Wanted %q for %s in %s; got %q, %v", c.want, c.key, c.locale, got, err)
+		}
+	}
+}
+
+func TestCatalogMissing(t *testing.T) {
+	c := newCatalog(t)
+
+	_, err := c.Lookup("en-GB", "missing", nil)
+	if merr, ok := err.(*i18n.MissingError); !ok || merr.Key != "missing" || merr.Locale != "en-GB" {
+		t.Errorf("Wanted *MissingError; got %v", err)
+	}
+	if got := c.Text("en-GB", "missing", nil); got != "missing" {
+		t.Errorf("Wanted key; got %q", got)
+	}
+}
+
+func TestCatalogFallbacks(t *testing.T) {
+	cat := i18n.NewCatalog("en-US")
+
+	cases := []struct {
+		locale string
+		want   []string
+	}{
+		{"en-GB", []string{"en-GB", "en", "en-US"}},
+		{"en-US", []string{"en-US", "en"}},
+		{"de-DE", []string{"de-DE", "de", "en-US", "en"}},
+		{"", []string{"en-US", "en"}},
+	}
+
+	for _, c := range cases {
+		got := cat.Fallbacks(c.locale)
+		if len(got) != len(c.want) {
+			t.Errorf("Wanted %v for %q; got %v", c.want, c.locale, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Errorf("Wanted %v for %q; got %v", c.want, c.locale, got)
+				break
+			}
+		}
+	}
+}
+
+func TestCatalogInvalidMessages(t *testing.T) {
+	docs := []string{
+		`{"items": {"one": "One item."}}`,
+		`{"items": {"some": "Some items.", "other": "Items."}}`,
+		`{"welcome": []}`,
+		`{"count": 3}`,
+		`{"welcome": ["Hi", 3]}`,
+		`not json`,
+	}
+
+	for _, doc := range docs {
+		if err := i18n.NewCatalog("en-US").AddJSON("en-US", []byte(doc)); err == nil {
+			t.Errorf("Wanted err for %s", doc)
+		}
+	}
+}
+
+func TestPlural(t *testing.T) {
+	cases := []struct {
+		locale string
+		n      int
+		want   i18n.PluralForm
+	}{
+		{"en-US", 0, i18n.Other},
+		{"en-US", 1, i18n.One},
+		{"en-US", 2, i18n.Other},
+		{"de-DE", 1, i18n.One},
+		{"fr-FR", 0, i18n.One},
+		{"fr-FR", 1, i18n.One},
+		{"fr-FR", 2, i18n.Other},
+		{"pt-BR", 0, i18n.One},
+		{"hi-IN", 1, i18n.One},
+		{"ja-JP", 1, i18n.Other},
+		{"ar-SA", 0, i18n.Zero},
+		{"ar-SA", 1, i18n.One},
+		{"ar-SA", 2, i18n.Two},
+		{"ar-SA", 5, i18n.Few},
+		{"ar-SA", 11, i18n.Many},
+		{"ar-SA", 100, i18n.Other},
+		{"ru-RU", 1, i18n.One},
+		{"ru-RU", 21, i18n.One},
+		{"ru-RU", 11, i18n.Many},
+		{"ru-RU", 3, i18n.Few},
+		{"ru-RU", 13, i18n.Many},
+		{"pl-PL", 21, i18n.Many},
+		{"pl-PL", 22, i18n.Few},
+		{"en-US", -1, i18n.One},
+	}
+
+	for _, c := range cases {
+		if got := i18n.Plural(c.locale, c.n); got != c.want {
+			t.Errorf("Wanted %s for %d in %s; got %s", c.want, c.n, c.locale, got)
+		}
+	}
+}
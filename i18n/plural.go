@@ -0,0 +1,107 @@
+package i18n
+
+// A PluralForm is a CLDR plural category.
+//
+// https://cldr.unicode.org/index/cldr-spec/plural-rules
+type PluralForm string
+
+// The plural forms a message may provide. Every message with plural forms
+// must provide Other.
+const (
+	Zero  PluralForm = "zero"
+	One   PluralForm = "one"
+	Two   PluralForm = "two"
+	Few   PluralForm = "few"
+	Many  PluralForm = "many"
+	Other PluralForm = "other"
+)
+
+func (f PluralForm) valid() bool {
+	switch f {
+	case Zero, One, Two, Few, Many, Other:
+		return true
+	}
+	return false
+}
+
+// pluralRules select the plural form of a count by language. Languages
+// without a rule use the rule of English.
+var pluralRules = map[string]func(n int) PluralForm{
+	"ar": arabicPlural,
+	"fr": zeroOnePlural,
+	"hi": zeroOnePlural,
+	"ja": otherPlural,
+	"ko": otherPlural,
+	"pl": polishPlural,
+	"pt": zeroOnePlural,
+	"ru": slavicPlural,
+	"uk": slavicPlural,
+	"zh": otherPlural,
+}
+
+// Plural returns the plural form of the count in the language of the locale.
+func Plural(locale string, n int) PluralForm {
+	if n < 0 {
+		n = -n
+	}
+	if rule, ok := pluralRules[language(locale)]; ok {
+		return rule(n)
+	}
+	return oneOtherPlural(n)
+}
+
+func oneOtherPlural(n int) PluralForm {
+	if n == 1 {
+		return One
+	}
+	return Other
+}
+
+func zeroOnePlural(n int) PluralForm {
+	if n == 0 || n == 1 {
+		return One
+	}
+	return Other
+}
+
+func otherPlural(n int) PluralForm {
+	return Other
+}
+
+func arabicPlural(n int) PluralForm {
+	switch mod := n % 100; {
+	case n == 0:
+		return Zero
+	case n == 1:
+		return One
+	case n == 2:
+		return Two
+	case mod >= 3 && mod <= 10:
+		return Few
+	case mod >= 11 && mod <= 99:
+		return Many
+	}
+	return Other
+}
+
+func slavicPlural(n int) PluralForm {
+	mod10, mod100 := n%10, n%100
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return One
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return Few
+	}
+	return Many
+}
+
+func polishPlural(n int) PluralForm {
+	mod10, mod100 := n%10, n%100
+	switch {
+	case n == 1:
+		return One
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return Few
+	}
+	return Many
+}
@@ -0,0 +1,218 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A yamlLine is a line of a YAML document holding content.
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+// A yamlParser parses the subset of YAML used by catalogs into the values
+// encoding/json would decode the equivalent JSON document into.
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func parseYAML(src string) (map[string]interface{}, error) {
+	p := &yamlParser{}
+	for i, raw := range strings.Split(src, "\n") {
+		raw = strings.TrimRight(raw, " \r")
+		trimmed := strings.TrimLeft(raw, " ")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || (i == 0 && trimmed == "---") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed in indentation", i+1)
+		}
+		p.lines = append(p.lines, yamlLine{number: i + 1, indent: len(raw) - len(trimmed), text: trimmed})
+	}
+
+	if len(p.lines) == 0 {
+		return map[string]interface{}{}, nil
+	}
+
+	doc, err := p.mapping(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, p.errorf("unexpected indentation")
+	}
+	return doc, nil
+}
+
+func (p *yamlParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", p.lines[p.pos].number, fmt.Sprintf(format, args...))
+}
+
+// block parses the list or mapping starting at the current line.
+func (p *yamlParser) block(indent int) (interface{}, error) {
+	if t := p.lines[p.pos].text; t == "-" || strings.HasPrefix(t, "- ") {
+		return p.list(indent)
+	}
+	return p.mapping(indent)
+}
+
+// mapping parses the keys at the indentation.
+func (p *yamlParser) mapping(indent int) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent {
+			return nil, p.errorf("unexpected indentation")
+		}
+
+		key, rest, err := splitKey(line.text)
+		if err != nil {
+			return nil, p.errorf("%s", err)
+		}
+		if _, ok := m[key]; ok {
+			return nil, p.errorf("duplicate key %q", key)
+		}
+		p.pos++
+
+		if rest != "" {
+			if m[key], err = scalar(rest); err != nil {
+				return nil, fmt.Errorf("line %d: %s", line.number, err)
+			}
+			continue
+		}
+
+		if p.pos >= len(p.lines) || p.lines[p.pos].indent <= indent {
+			return nil, fmt.Errorf("line %d: missing value for %q", line.number, key)
+		}
+		if m[key], err = p.block(p.lines[p.pos].indent); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// list parses the items at the indentation. Items must be scalars.
+func (p *yamlParser) list(indent int) ([]interface{}, error) {
+	var items []interface{}
+
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent {
+			return nil, p.errorf("unexpected indentation")
+		}
+		if line.text != "-" && !strings.HasPrefix(line.text, "- ") {
+			return nil, p.errorf("expected a list item")
+		}
+
+		item, err := scalar(strings.TrimPrefix(line.text, "-"))
+		if err != nil {
+			return nil, p.errorf("%s", err)
+		}
+		items = append(items, item)
+		p.pos++
+	}
+
+	return items, nil
+}
+
+// splitKey splits a mapping line into its key and the rest of the line after
+// the colon.
+func splitKey(text string) (string, string, error) {
+	if text[0] == '"' || text[0] == '\'' {
+		key, rest, err := quoted(text)
+		if err != nil {
+			return "", "", err
+		}
+		if !strings.HasPrefix(rest, ":") {
+			return "", "", fmt.Errorf("expected a colon after key %q", key)
+		}
+		return key, strings.TrimSpace(rest[1:]), nil
+	}
+
+	for i := 0; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			return strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:]), nil
+		}
+	}
+	return "", "", fmt.Errorf("expected key: value")
+}
+
+// scalar parses a plain or quoted string and any trailing comment.
+func scalar(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", fmt.Errorf("missing value")
+	}
+
+	switch s[0] {
+	case '"', '\'':
+		v, rest, err := quoted(s)
+		if err != nil {
+			return "", err
+		}
+		if rest = strings.TrimSpace(rest); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", fmt.Errorf("unexpected %q after quoted string", rest)
+		}
+		return v, nil
+	case '[', '{':
+		return "", fmt.Errorf("flow collections are not supported")
+	case '|', '>':
+		return "", fmt.Errorf("block scalars are not supported")
+	}
+
+	if i := strings.Index(s, " #"); i >= 0 {
+		s = strings.TrimSpace(s[:i])
+	}
+	return s, nil
+}
+
+// quoted parses the quoted string at the start of s and returns it along
+// with the rest of s.
+func quoted(s string) (string, string, error) {
+	q := s[0]
+	var b strings.Builder
+
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case q == '\'' && c == '\'':
+			if i+1 < len(s) && s[i+1] == '\'' {
+				b.WriteByte('\'')
+				i++
+				continue
+			}
+			return b.String(), s[i+1:], nil
+		case q == '"' && c == '"':
+			return b.String(), s[i+1:], nil
+		case q == '"' && c == '\\':
+			if i+1 == len(s) {
+				return "", "", fmt.Errorf("unterminated escape")
+			}
+			i++
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case '"', '\\', '/':
+				b.WriteByte(s[i])
+			default:
+				return "", "", fmt.Errorf("unsupported escape \\%c", s[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", "", fmt.Errorf("unterminated string")
+}
@@ -0,0 +1,64 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseYAML(t *testing.T) {
+	doc, err := parseYAML(`---
+# Greetings
+greeting: Hello {name}!  # trailing comment
+"quoted key": 'It''s here'
+escaped: "Line one\nLine \"two\" # not a comment"
+url: https://example.com/a#b
+welcome:
+  - Welcome back!
+  - "Good to see you: again!"
+items:
+  one:
+    - One item.
+    - A single item.
+  other: '{count} items.'
+`)
+	if err != nil {
+		t.Fatalf("Did not want err; got %s", err)
+	}
+
+	want := map[string]interface{}{
+		"greeting":   "Hello {name}!",
+		"quoted key": "It's here",
+		"escaped":    "Line one\nLine \"two\" # not a comment",
+		"url":        "https://example.com/a#b",
+		"welcome":    []interface{}{"Welcome back!", "Good to see you: again!"},
+		"items": map[string]interface{}{
+			"one":   []interface{}{"One item.", "A single item."},
+			"other": "{count} items.",
+		},
+	}
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("Wanted %#v; got %#v", want, doc)
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	docs := []string{
+		"greeting",
+		"greeting: \"unterminated",
+		"greeting: 'a' b",
+		"greeting: [a, b]",
+		"greeting: |",
+		"greeting:",
+		"a: b\na: c",
+		"a: b\n  c: d",
+		"items:\n  - a\n  b: c",
+		"\tgreeting: hi",
+		"greeting: \"\\q\"",
+	}
+
+	for _, doc := range docs {
+		if _, err := parseYAML(doc); err == nil {
+			t.Errorf("Wanted err for %q", doc)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package alexa
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+const (
+	householdListsPath = "/v2/householdlists/"
+
+	// ActiveListState is the state of a list or item that is in use.
+	ActiveListState = "active"
+	// ArchivedListState is the state of a list that is no longer in use.
+	ArchivedListState = "archived"
+	// CompletedListItemStatus is the status of an item that has been checked
+	// off.
+	CompletedListItemStatus = "completed"
+)
+
+// ListMetadata describes a household list without its items.
+type ListMetadata struct {
+	ListID    string `json:"listId"`
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	Version   int    `json:"version"`
+	StatusMap []struct {
+		Href   string `json:"href"`
+		Status string `json:"status"`
+	} `json:"statusMap"`
+}
+
+// A List is a household list with the items of a single status.
+type List struct {
+	ListID  string     `json:"listId"`
+	Name    string     `json:"name"`
+	State   string     `json:"state"`
+	Version int        `json:"version"`
+	Items   []ListItem `json:"items"`
+}
+
+// A ListItem is a single entry of a household list.
+type ListItem struct {
+	ID          string `json:"id,omitempty"`
+	Version     int    `json:"version,omitempty"`
+	Value       string `json:"value"`
+	Status      string `json:"status"`
+	CreatedTime string `json:"createdTime,omitempty"`
+	UpdatedTime string `json:"updatedTime,omitempty"`
+	Href        string `json:"href,omitempty"`
+}
+
+// A ListsClient reads and modifies the household to-do and shopping lists of
+// a customer with the Lists API. Reads require the ReadListsPermission and
+// modifications require the WriteListsPermission.
+//
+// https://developer.amazon.com/docs/custom-skills/access-the-alexa-shopping-and-to-do-lists.html
+type ListsClient struct {
+	*ServiceClient
+}
+
+// NewListsClient returns a ListsClient for the apiEndpoint and apiAccessToken
+// provided in the context of a request.
+func NewListsClient(apiEndpoint, apiAccessToken string) *ListsClient {
+	return &ListsClient{NewServiceClient(apiEndpoint, apiAccessToken)}
+}
+
+// Lists returns the metadata of every list of the customer.
+func (c *ListsClient) Lists(ctx context.Context) ([]ListMetadata, error) {
+	var out struct {
+		Lists []ListMetadata `json:"lists"`
+	}
+	if err := c.read(ctx, householdListsPath, &out); err != nil {
+		return nil, err
+	}
+	return out.Lists, nil
+}
+
+// List returns the list with only the items of the given status, either
+// ActiveListState or CompletedListItemStatus.
+func (c *ListsClient) List(ctx context.Context, listID, status string) (*List, error) {
+	l := &List{}
+	if err := c.read(ctx, listPath(listID)+"/"+url.PathEscape(status), l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// CreateList creates an active custom list with the given name.
+func (c *ListsClient) CreateList(ctx context.Context, name string) (*ListMetadata, error) {
+	l := &ListMetadata{}
+	in := struct {
+		Name  string `json:"name"`
+		State string `json:"state"`
+	}{name, ActiveListState}
+	if err := c.write(ctx, http.MethodPost, householdListsPath, in, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// DeleteList deletes a custom list.
+func (c *ListsClient) DeleteList(ctx context.Context, listID string) error {
+	return c.write(ctx, http.MethodDelete, listPath(listID), nil, nil)
+}
+
+// Item returns a single item of a list.
+func (c *ListsClient) Item(ctx context.Context, listID, itemID string) (*ListItem, error) {
+	item := &ListItem{}
+	if err := c.read(ctx, itemPath(listID, itemID), item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// CreateItem adds an active item with the given value to a list.
+func (c *ListsClient) CreateItem(ctx context.Context, listID, value string) (*ListItem, error) {
+	item := &ListItem{}
+	in := ListItem{Value: value, Status: ActiveListState}
+	if err := c.write(ctx, http.MethodPost, listPath(listID)+"/items", in, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// UpdateItem replaces the value and status of an item. The Version of the
+// item must match the version stored by Alexa.
+func (c *ListsClient) UpdateItem(ctx context.Context, listID string, item ListItem) (*ListItem, error) {
+	updated := &ListItem{}
+	in := ListItem{Value: item.Value, Status: item.Status, Version: item.Version}
+	if err := c.write(ctx, http.MethodPut, itemPath(listID, item.ID), in, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteItem removes an item from a list.
+func (c *ListsClient) DeleteItem(ctx context.Context, listID, itemID string) error {
+	return c.write(ctx, http.MethodDelete, itemPath(listID, itemID), nil, nil)
+}
+
+// read sends a request requiring the ReadListsPermission.
+func (c *ListsClient) read(ctx context.Context, path string, out interface{}) error {
+	return permissionError(c.Do(ctx, http.MethodGet, path, nil, out), ReadListsPermission)
+}
+
+// write sends a request requiring the WriteListsPermission.
+func (c *ListsClient) write(ctx context.Context, method, path string, in, out interface{}) error {
+	return permissionError(c.Do(ctx, method, path, in, out), WriteListsPermission)
+}
+
+func listPath(listID string) string {
+	return householdListsPath + url.PathEscape(listID)
+}
+
+func itemPath(listID, itemID string) string {
+	return listPath(listID) + "/items/" + url.PathEscape(itemID)
+}
@@ -0,0 +1,71 @@
+package alexa_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/benjic/alexa"
+)
+
+func TestListsClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v2/householdlists/":
+			w.Write([]byte(`{"lists": [{"listId": "shopping", "name": "Alexa shopping list", "state": "active", "version": 1}]}`))
+		case "GET /v2/householdlists/shopping/active":
+			w.Write([]byte(`{"listId": "shopping", "items": [{"id": "milk", "value": "milk", "status": "active", "version": 1}]}`))
+		case "POST /v2/householdlists/shopping/items":
+			var item alexa.ListItem
+			json.NewDecoder(r.Body).Decode(&item)
+			item.ID = "eggs"
+			item.Version = 1
+			json.NewEncoder(w).Encode(item)
+		case "PUT /v2/householdlists/shopping/items/milk":
+			var item alexa.ListItem
+			json.NewDecoder(r.Body).Decode(&item)
+			if item.Version != 1 || item.Status != "completed" {
+				t.Errorf("Unexpected update %+v", item)
+			}
+			item.ID = "milk"
+			item.Version++
+			json.NewEncoder(w).Encode(item)
+		case "DELETE /v2/householdlists/todo":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := alexa.NewListsClient(srv.URL, "token")
+	ctx := context.Background()
+
+	ls, err := c.Lists(ctx)
+	if err != nil || len(ls) != 1 || ls[0].ListID != "shopping" {
+		t.Fatalf("Unexpected lists %+v, %v", ls, err)
+	}
+
+	l, err := c.List(ctx, "shopping", alexa.ActiveListState)
+	if err != nil || len(l.Items) != 1 || l.Items[0].Value != "milk" {
+		t.Fatalf("Unexpected list %+v, %v", l, err)
+	}
+
+	item, err := c.CreateItem(ctx, "shopping", "eggs")
+	if err != nil || item.ID != "eggs" || item.Status != alexa.ActiveListState {
+		t.Errorf("Unexpected item %+v, %v", item, err)
+	}
+
+	milk := l.Items[0]
+	milk.Status = alexa.CompletedListItemStatus
+	if item, err := c.UpdateItem(ctx, "shopping", milk); err != nil || item.Version != 2 {
+		t.Errorf("Unexpected item %+v, %v", item, err)
+	}
+
+	err = c.DeleteList(ctx, "todo")
+	if perr, ok := err.(*alexa.PermissionError); !ok || perr.Permissions[0] != alexa.WriteListsPermission {
+		t.Errorf("Wanted *PermissionError for %s; got %v", alexa.WriteListsPermission, err)
+	}
+}
@@ -0,0 +1,135 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	lwaTokenURL = "https://api.amazon.com/auth/o2/token"
+
+	// lwaExpiryMargin is how long before expiry a cached token is refreshed so
+	// it does not expire while a request is in flight.
+	lwaExpiryMargin = time.Minute
+)
+
+// A TokenSource provides access tokens for the Alexa service APIs used outside
+// of a request to a skill.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// An LWATokenSource obtains access tokens from Login with Amazon with the
+// client credentials of a skill. Tokens are cached until shortly before they
+// expire. An LWATokenSource is safe for concurrent use.
+type LWATokenSource struct {
+	ClientID     string
+	ClientSecret string
+	Scope        string
+	TokenURL     string
+	HTTPClient   *http.Client
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// NewLWATokenSource returns an LWATokenSource for the client credentials found
+// in the permissions section of the skill in the developer console. The scope
+// depends on the API the tokens are used for, such as ProactiveEventsScope.
+func NewLWATokenSource(clientID, clientSecret, scope string) *LWATokenSource {
+	return &LWATokenSource{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scope:        scope,
+		TokenURL:     lwaTokenURL,
+		HTTPClient:   http.DefaultClient,
+	}
+}
+
+// Token returns a cached access token or obtains a new one if the cached token
+// is about to expire.
+func (s *LWATokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Now().Before(s.expiry.Add(-lwaExpiryMargin)) {
+		return s.token, nil
+	}
+
+	token, expiresIn, err := s.request(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	s.token = token
+	s.expiry = time.Now().Add(expiresIn)
+	return s.token, nil
+}
+
+// request obtains a new access token and its lifetime.
+func (s *LWATokenSource) request(ctx context.Context) (string, time.Duration, error) {
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {s.ClientID},
+		"client_secret": {s.ClientSecret},
+		"scope":         {s.Scope},
+	}
+
+	r, err := http.NewRequest(http.MethodPost, s.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, err
+	}
+	r = r.WithContext(ctx)
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(r)
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	decodeErr := json.NewDecoder(resp.Body).Decode(&payload)
+
+	if resp.StatusCode >= 300 {
+		return "", 0, &ServiceError{
+			StatusCode: resp.StatusCode,
+			Code:       payload.Error,
+			Message:    payload.ErrorDescription,
+		}
+	}
+	if decodeErr != nil {
+		return "", 0, decodeErr
+	}
+
+	return payload.AccessToken, time.Duration(payload.ExpiresIn) * time.Second, nil
+}
+
+// authorize returns a copy of the client using an access token from the
+// source.
+func authorize(ctx context.Context, c *ServiceClient, tokens TokenSource) (*ServiceClient, error) {
+	token, err := tokens.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	authorized := *c
+	authorized.APIAccessToken = token
+	return &authorized, nil
+}
@@ -0,0 +1,77 @@
+package alexa_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/benjic/alexa"
+)
+
+// newLWAServer returns a stand-in for Login with Amazon issuing tokens that
+// expire after expiresIn seconds. The number of tokens issued is counted in
+// issued.
+func newLWAServer(t *testing.T, expiresIn int, issued *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("client_id") != "id" || r.Form.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "invalid_client", "error_description": "Client authentication failed"}`))
+			return
+		}
+
+		*issued++
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "token",
+			"expires_in":   expiresIn,
+			"scope":        r.Form.Get("scope"),
+			"token_type":   "bearer",
+		})
+	}))
+}
+
+func TestLWATokenSourceCaches(t *testing.T) {
+	cases := []struct {
+		expiresIn int
+		issued    int
+	}{
+		{3600, 1},
+		// Tokens within the expiry margin are refreshed.
+		{30, 2},
+	}
+
+	for _, c := range cases {
+		issued := 0
+		srv := newLWAServer(t, c.expiresIn, &issued)
+
+		s := alexa.NewLWATokenSource("id", "secret", alexa.ProactiveEventsScope)
+		s.TokenURL = srv.URL
+
+		for i := 0; i < 2; i++ {
+			if token, err := s.Token(context.Background()); err != nil || token != "token" {
+				t.Errorf("Wanted token; got %q, %v", token, err)
+			}
+		}
+		srv.Close()
+
+		if issued != c.issued {
+			t.Errorf("Wanted %d tokens issued; got %d for expiry of %ds", c.issued, issued, c.expiresIn)
+		}
+	}
+}
+
+func TestLWATokenSourceError(t *testing.T) {
+	issued := 0
+	srv := newLWAServer(t, 3600, &issued)
+	defer srv.Close()
+
+	s := alexa.NewLWATokenSource("id", "wrong", alexa.ProactiveEventsScope)
+	s.TokenURL = srv.URL
+
+	_, err := s.Token(context.Background())
+	if serr, ok := err.(*alexa.ServiceError); !ok || serr.Code != "invalid_client" {
+		t.Errorf("Wanted invalid_client *ServiceError; got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package alexa
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+const inSkillProductsPath = "/v1/users/~current/skills/~current/inSkillProducts"
+
+// A ProductType is the kind of an in-skill product.
+type ProductType string
+
+// The kinds of in-skill product a skill is able to sell.
+const (
+	SubscriptionProductType ProductType = "SUBSCRIPTION"
+	EntitlementProductType  ProductType = "ENTITLEMENT"
+	ConsumableProductType   ProductType = "CONSUMABLE"
+)
+
+// An EntitlementState reports whether a customer owns an in-skill product.
+type EntitlementState string
+
+// The entitlement states of an in-skill product.
+const (
+	Entitled    EntitlementState = "ENTITLED"
+	NotEntitled EntitlementState = "NOT_ENTITLED"
+)
+
+// A PurchasableState reports whether a customer is able to buy an in-skill
+// product.
+type PurchasableState string
+
+// The purchasable states of an in-skill product.
+const (
+	Purchasable    PurchasableState = "PURCHASABLE"
+	NotPurchasable PurchasableState = "NOT_PURCHASABLE"
+)
+
+// An InSkillProduct is a product sold by the skill as seen by the customer
+// making the request.
+type InSkillProduct struct {
+	ProductID              string           `json:"productId"`
+	ReferenceName          string           `json:"referenceName"`
+	Type                   ProductType      `json:"type"`
+	Name                   string           `json:"name"`
+	Summary                string           `json:"summary"`
+	Entitled               EntitlementState `json:"entitled"`
+	EntitlementReason      string           `json:"entitlementReason"`
+	Purchasable            PurchasableState `json:"purchasable"`
+	ActiveEntitlementCount int              `json:"activeEntitlementCount"`
+	PurchaseMode           string           `json:"purchaseMode"`
+}
+
+// Owned reports whether the customer is entitled to the product.
+func (p InSkillProduct) Owned() bool {
+	return p.Entitled == Entitled
+}
+
+// A ProductFilter limits the products returned by a MonetizationClient. Empty
+// fields match every product.
+type ProductFilter struct {
+	Type        ProductType
+	Entitled    EntitlementState
+	Purchasable PurchasableState
+}
+
+func (f ProductFilter) query() url.Values {
+	q := url.Values{}
+	if f.Type != "" {
+		q.Set("productType", string(f.Type))
+	}
+	if f.Entitled != "" {
+		q.Set("entitled", string(f.Entitled))
+	}
+	if f.Purchasable != "" {
+		q.Set("purchasable", string(f.Purchasable))
+	}
+	return q
+}
+
+// A MonetizationClient reads the in-skill products of a skill with the
+// Monetization Service API. Product names and summaries are returned in the
+// Locale of the request.
+//
+// https://developer.amazon.com/docs/in-skill-purchase/in-skill-product-service.html
+type MonetizationClient struct {
+	*ServiceClient
+	Locale string
+}
+
+// NewMonetizationClient returns a MonetizationClient for the apiEndpoint,
+// apiAccessToken and locale provided in a request.
+func NewMonetizationClient(apiEndpoint, apiAccessToken, locale string) *MonetizationClient {
+	return &MonetizationClient{
+		ServiceClient: NewServiceClient(apiEndpoint, apiAccessToken),
+		Locale:        locale,
+	}
+}
+
+// Products returns every product matching the filter. Truncated results are
+// followed until all pages have been read.
+func (c *MonetizationClient) Products(ctx context.Context, f ProductFilter) ([]InSkillProduct, error) {
+	q := f.query()
+	products := []InSkillProduct{}
+
+	for {
+		var page struct {
+			InSkillProducts []InSkillProduct `json:"inSkillProducts"`
+			IsTruncated     bool             `json:"isTruncated"`
+			NextToken       string           `json:"nextToken"`
+		}
+
+		path := inSkillProductsPath
+		if len(q) > 0 {
+			path += "?" + q.Encode()
+		}
+		if err := c.get(ctx, path, &page); err != nil {
+			return nil, err
+		}
+
+		products = append(products, page.InSkillProducts...)
+		if !page.IsTruncated || page.NextToken == "" {
+			return products, nil
+		}
+		q.Set("nextToken", page.NextToken)
+	}
+}
+
+// Product returns the product with the given id.
+func (c *MonetizationClient) Product(ctx context.Context, productID string) (*InSkillProduct, error) {
+	p := &InSkillProduct{}
+	if err := c.get(ctx, inSkillProductsPath+"/"+url.PathEscape(productID), p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// get reads the resource at path in the locale of the client.
+func (c *MonetizationClient) get(ctx context.Context, path string, out interface{}) error {
+	header := http.Header{}
+	if c.Locale != "" {
+		header.Set("Accept-Language", c.Locale)
+	}
+	return c.do(ctx, http.MethodGet, path, header, nil, out)
+}
@@ -0,0 +1,75 @@
+package alexa_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/benjic/alexa"
+)
+
+func TestMonetizationClientProducts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if lang := r.Header.Get("Accept-Language"); lang != "en-GB" {
+			t.Errorf("Wanted en-GB; got %q", lang)
+		}
+		if r.URL.Path != "/v1/users/~current/skills/~current/inSkillProducts" {
+			t.Errorf("Unexpected request for %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("productType") != "SUBSCRIPTION" {
+			t.Errorf("Wanted SUBSCRIPTION filter; got %q", r.URL.RawQuery)
+		}
+
+		switch r.URL.Query().Get("nextToken") {
+		case "":
+			w.Write([]byte(`{
+				"inSkillProducts": [{
+					"productId": "amzn1.adg.product.ONE",
+					"referenceName": "premium",
+					"type": "SUBSCRIPTION",
+					"name": "Premium",
+					"summary": "Twice the facts.",
+					"entitled": "ENTITLED",
+					"entitlementReason": "PURCHASED",
+					"purchasable": "NOT_PURCHASABLE",
+					"activeEntitlementCount": 1,
+					"purchaseMode": "LIVE"
+				}],
+				"isTruncated": true,
+				"nextToken": "page-2"
+			}`))
+		case "page-2":
+			w.Write([]byte(`{
+				"inSkillProducts": [{
+					"productId": "amzn1.adg.product.TWO",
+					"referenceName": "deluxe",
+					"type": "SUBSCRIPTION",
+					"entitled": "NOT_ENTITLED",
+					"purchasable": "PURCHASABLE"
+				}],
+				"isTruncated": false,
+				"nextToken": null
+			}`))
+		default:
+			t.Errorf("Unexpected token %q", r.URL.Query().Get("nextToken"))
+		}
+	}))
+	defer srv.Close()
+
+	c := alexa.NewMonetizationClient(srv.URL, "token", "en-GB")
+	products, err := c.Products(context.Background(), alexa.ProductFilter{Type: alexa.SubscriptionProductType})
+	if err != nil {
+		t.Fatalf("Did not want err; got %s", err)
+	}
+
+	if len(products) != 2 {
+		t.Fatalf("Wanted 2 products; got %+v", products)
+	}
+	if !products[0].Owned() || products[0].ActiveEntitlementCount != 1 {
+		t.Errorf("Wanted premium to be owned; got %+v", products[0])
+	}
+	if products[1].Owned() || products[1].Purchasable != alexa.Purchasable {
+		t.Errorf("Wanted deluxe to be purchasable; got %+v", products[1])
+	}
+}
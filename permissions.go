@@ -0,0 +1,129 @@
+package alexa
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// A Permission is a scope of customer data a skill must be granted before it
+// is able to read it with the Alexa service APIs.
+type Permission string
+
+// The permissions a skill is able to request.
+const (
+	FullAddressPermission          Permission = "read::alexa:device:all:address"
+	CountryAndPostalCodePermission Permission = "read::alexa:device:all:address:country_and_postal_code"
+	GeolocationPermission          Permission = "alexa::devices:all:geolocation:read"
+
+	NamePermission         Permission = "alexa::profile:name:read"
+	GivenNamePermission    Permission = "alexa::profile:given_name:read"
+	EmailPermission        Permission = "alexa::profile:email:read"
+	MobileNumberPermission Permission = "alexa::profile:mobile_number:read"
+
+	ReadListsPermission  Permission = "read::alexa:household:list"
+	WriteListsPermission Permission = "write::alexa:household:list"
+
+	RemindersPermission     Permission = "alexa::alerts:reminders:skill:readwrite"
+	TimersPermission        Permission = "alexa::alerts:timers:skill:readwrite"
+	NotificationsPermission Permission = "alexa::devices:all:notifications:write"
+)
+
+// A PermissionStatus is whether a customer has granted a skill a permission.
+type PermissionStatus string
+
+// The statuses of a permission.
+const (
+	PermissionGranted PermissionStatus = "GRANTED"
+	PermissionDenied  PermissionStatus = "DENIED"
+	// PermissionUnknown is the status of a permission Alexa does not report
+	// individually once the customer has granted the skill some permission.
+	PermissionUnknown PermissionStatus = "UNKNOWN"
+)
+
+// permissions holds every permission a skill is able to request.
+var permissions = map[Permission]bool{
+	FullAddressPermission:          true,
+	CountryAndPostalCodePermission: true,
+	GeolocationPermission:          true,
+	NamePermission:                 true,
+	GivenNamePermission:            true,
+	EmailPermission:                true,
+	MobileNumberPermission:         true,
+	ReadListsPermission:            true,
+	WriteListsPermission:           true,
+	RemindersPermission:            true,
+	TimersPermission:               true,
+	NotificationsPermission:        true,
+}
+
+// KnownPermissions returns every permission a skill is able to request in
+// lexical order.
+func KnownPermissions() []Permission {
+	ps := make([]Permission, 0, len(permissions))
+	for p := range permissions {
+		ps = append(ps, p)
+	}
+	sort.Slice(ps, func(i, j int) bool { return ps[i] < ps[j] })
+	return ps
+}
+
+// Known reports whether the permission is one a skill is able to request.
+func (p Permission) Known() bool {
+	return permissions[p]
+}
+
+// Permissions describes the permissions a customer has granted a skill.
+type Permissions struct {
+	// ConsentToken is provided once the customer has granted the skill any
+	// permission.
+	ConsentToken string `json:"consentToken"`
+	// Scopes holds the status of permissions Alexa reports individually, such
+	// as the RemindersPermission.
+	Scopes map[Permission]struct {
+		Status string `json:"status"`
+	} `json:"scopes"`
+}
+
+// Status returns whether the customer has granted the permission. Alexa only
+// reports the status of some permissions individually; for the rest a consent
+// token only indicates that some permission has been granted, so the status is
+// PermissionUnknown and a service API may still respond with a
+// *PermissionError.
+func (ps Permissions) Status(p Permission) PermissionStatus {
+	if scope, ok := ps.Scopes[p]; ok {
+		if PermissionStatus(scope.Status) == PermissionGranted {
+			return PermissionGranted
+		}
+		return PermissionDenied
+	}
+	if ps.ConsentToken != "" {
+		return PermissionUnknown
+	}
+	return PermissionDenied
+}
+
+// A PermissionError is returned when an Alexa service API refuses a request
+// because the customer has not granted the skill a permission. The
+// AskForPermissionsConsentCard of a Response can be used to ask the customer to
+// grant the permissions.
+type PermissionError struct {
+	Permissions []Permission
+}
+
+func (e *PermissionError) Error() string {
+	ps := make([]string, len(e.Permissions))
+	for i, p := range e.Permissions {
+		ps[i] = string(p)
+	}
+	return "permission not granted: " + strings.Join(ps, ", ")
+}
+
+// permissionError reports a forbidden response from an Alexa service API as
+// the permissions not being granted. Any other err is returned unchanged.
+func permissionError(err error, ps ...Permission) error {
+	if serr, ok := err.(*ServiceError); ok && serr.StatusCode == http.StatusForbidden {
+		return &PermissionError{Permissions: ps}
+	}
+	return err
+}
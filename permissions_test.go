@@ -0,0 +1,61 @@
+package alexa_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/benjic/alexa"
+)
+
+func TestPermissionsStatus(t *testing.T) {
+	req := &alexa.IntentRequest{}
+	err := json.Unmarshal([]byte(`{
+		"context": {
+			"System": {
+				"user": {
+					"userId": "amzn1.ask.account.TEST",
+					"permissions": {
+						"consentToken": "token",
+						"scopes": {
+							"alexa::alerts:reminders:skill:readwrite": {"status": "GRANTED"},
+							"alexa::alerts:timers:skill:readwrite": {"status": "DENIED"}
+						}
+					}
+				}
+			}
+		}
+	}`), req)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal: %s", err)
+	}
+
+	cases := []struct {
+		permissions alexa.Permissions
+		permission  alexa.Permission
+		status      alexa.PermissionStatus
+	}{
+		{req.Context.System.User.Permissions, alexa.RemindersPermission, alexa.PermissionGranted},
+		{req.Context.System.User.Permissions, alexa.TimersPermission, alexa.PermissionDenied},
+		{req.Context.System.User.Permissions, alexa.FullAddressPermission, alexa.PermissionUnknown},
+		{alexa.Permissions{}, alexa.FullAddressPermission, alexa.PermissionDenied},
+		{alexa.Permissions{}, alexa.RemindersPermission, alexa.PermissionDenied},
+	}
+
+	for _, c := range cases {
+		if status := c.permissions.Status(c.permission); status != c.status {
+			t.Errorf("Wanted %s; got %s for %s with %+v", c.status, status, c.permission, c.permissions)
+		}
+	}
+}
+
+func TestKnownPermissions(t *testing.T) {
+	for _, p := range alexa.KnownPermissions() {
+		if !p.Known() {
+			t.Errorf("Wanted %s to be known", p)
+		}
+	}
+
+	if alexa.Permission("read::alexa:unknown").Known() {
+		t.Errorf("Did not want unknown permission to be known")
+	}
+}
@@ -0,0 +1,231 @@
+// Package playlist plays a sequence of audio tracks with the Alexa AudioPlayer
+// interface.
+//
+// A Playlist answers the AudioPlayer and PlaybackController requests made
+// while its tracks play. The position of a track is encoded into the stream
+// token so no state is needed to enqueue the next track, and the progress of
+// each listener is saved to a Store so playback can be resumed later.
+//
+//	p := &playlist.Playlist{ID: "podcast", Tracks: tracks, Store: playlist.NewMemoryStore()}
+//	h := &alexa.Handler{}
+//	p.Install(h)
+package playlist
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/benjic/alexa"
+)
+
+// ErrUnknownToken is returned when a stream token was not created by the
+// playlist.
+var ErrUnknownToken = errors.New("token does not belong to playlist")
+
+// ErrNoTrack is returned when playing a track outside the tracks of the
+// playlist, including any track of a playlist without tracks.
+var ErrNoTrack = errors.New("no track at index")
+
+// A Track is a single audio stream in a Playlist.
+type Track struct {
+	URL      string
+	Metadata *alexa.AudioItemMetadata
+}
+
+// A Playlist plays its tracks in order.
+type Playlist struct {
+	// ID distinguishes the tokens of the playlist from those of any other
+	// audio the skill plays. It must not contain a colon.
+	ID     string
+	Tracks []Track
+	Store  Store
+}
+
+// Install sets the AudioPlayer and PlaybackController functions of the handler
+// to those of the playlist.
+func (p *Playlist) Install(h *alexa.Handler) {
+	h.AudioPlaybackFailedRequest = p.PlaybackFailed
+	h.AudioPlaybackFinishedRequest = p.PlaybackFinished
+	h.AudioPlaybackNearlyFinishedRequest = p.PlaybackNearlyFinished
+	h.AudioPlaybackStartedRequest = p.PlaybackStarted
+	h.AudioPlaybackStoppedRequest = p.PlaybackStopped
+
+	h.PlaybackControllerNextCommandRequest = p.Next
+	h.PlaybackControllerPauseCommandRequest = p.Pause
+	h.PlaybackControllerPlayCommandRequest = p.Resume
+	h.PlaybackControllerPreviousCommandRequest = p.Previous
+}
+
+// Token returns the stream token for the track at index.
+func (p *Playlist) Token(index int) string {
+	return p.ID + ":" + strconv.Itoa(index)
+}
+
+// Index returns the index of the track the stream token was created for.
+func (p *Playlist) Index(token string) (int, error) {
+	i := strings.LastIndex(token, ":")
+	if i < 0 || token[:i] != p.ID {
+		return 0, ErrUnknownToken
+	}
+
+	index, err := strconv.Atoi(token[i+1:])
+	if err != nil || index < 0 || index >= len(p.Tracks) {
+		return 0, ErrUnknownToken
+	}
+
+	return index, nil
+}
+
+// Play resumes playback for the user from their saved progress. Playback
+// starts from the first track when no progress has been saved. ErrNoTrack is
+// returned when the playlist has no tracks.
+func (p *Playlist) Play(resp alexa.AudioPlayer, userID string) error {
+	if len(p.Tracks) == 0 {
+		return ErrNoTrack
+	}
+
+	progress, err := p.Store.Load(p.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	if progress.Index < 0 || progress.Index >= len(p.Tracks) {
+		progress = Progress{}
+	}
+
+	return p.PlayTrack(resp, progress.Index, progress.OffsetInMilliseconds)
+}
+
+// PlayTrack immediately plays the track at index from the given offset.
+// ErrNoTrack is returned when the playlist has no track at index.
+func (p *Playlist) PlayTrack(resp alexa.AudioPlayer, index, offsetInMilliseconds int) error {
+	t, ok := p.track(index)
+	if !ok {
+		return ErrNoTrack
+	}
+
+	resp.ReplaceAllAudio(alexa.PlayRequest{
+		Token:                p.Token(index),
+		URL:                  t.URL,
+		OffsetInMilliseconds: offsetInMilliseconds,
+		Metadata:             t.Metadata,
+	})
+	return nil
+}
+
+// track returns the track at index if the playlist has one.
+func (p *Playlist) track(index int) (Track, bool) {
+	if index < 0 || index >= len(p.Tracks) {
+		return Track{}, false
+	}
+	return p.Tracks[index], true
+}
+
+// PlaybackStarted saves the progress of the user when a track starts.
+func (p *Playlist) PlaybackStarted(resp alexa.AudioStopperQueueClearer, req *alexa.AudioPlaybackRequest) error {
+	return p.save(req.Context.System.User.ID, req.Request.Token, req.Request.OffsetInMilliseconds)
+}
+
+// PlaybackNearlyFinished enqueues the track following the one playing. Nothing
+// is enqueued once the last track plays.
+func (p *Playlist) PlaybackNearlyFinished(resp alexa.AudioPlayerStopperQueueClearer, req *alexa.AudioPlaybackRequest) error {
+	index, err := p.Index(req.Request.Token)
+	if err != nil {
+		return nil
+	}
+
+	t, ok := p.track(index + 1)
+	if !ok {
+		return nil
+	}
+	resp.EnqueueAudio(alexa.PlayRequest{
+		Token:                 p.Token(index + 1),
+		URL:                   t.URL,
+		ExpectedPreviousToken: req.Request.Token,
+		Metadata:              t.Metadata,
+	})
+	return nil
+}
+
+// PlaybackFinished saves the progress of the user as the start of the next
+// track. Progress returns to the first track once the last track finishes.
+func (p *Playlist) PlaybackFinished(resp alexa.AudioStopperQueueClearer, req *alexa.AudioPlaybackRequest) error {
+	index, err := p.Index(req.Request.Token)
+	if err != nil {
+		return nil
+	}
+
+	next := index + 1
+	if next >= len(p.Tracks) {
+		next = 0
+	}
+	return p.Store.Save(p.ID, req.Context.System.User.ID, Progress{Index: next})
+}
+
+// PlaybackStopped saves the progress of the user so playback can be resumed
+// from the same offset.
+func (p *Playlist) PlaybackStopped(req *alexa.AudioPlaybackRequest) error {
+	return p.save(req.Context.System.User.ID, req.Request.Token, req.Request.OffsetInMilliseconds)
+}
+
+// PlaybackFailed skips to the track following the one that failed.
+func (p *Playlist) PlaybackFailed(resp alexa.AudioPlayerStopperQueueClearer, req *alexa.AudioPlaybackFailedRequest) error {
+	index, err := p.Index(req.Request.Token)
+	if err != nil || index+1 >= len(p.Tracks) {
+		return nil
+	}
+
+	return p.PlayTrack(resp, index+1, 0)
+}
+
+// Next plays the track following the one playing.
+func (p *Playlist) Next(resp alexa.AudioPlayerStopperQueueClearer, req *alexa.PlaybackControllerRequest) error {
+	index, err := p.Index(req.Context.AudioPlayer.Token)
+	if err != nil {
+		return nil
+	}
+
+	if index+1 >= len(p.Tracks) {
+		resp.StopAudio()
+		return nil
+	}
+
+	return p.PlayTrack(resp, index+1, 0)
+}
+
+// Previous plays the track preceding the one playing. The first track is
+// restarted when it is playing.
+func (p *Playlist) Previous(resp alexa.AudioPlayerStopperQueueClearer, req *alexa.PlaybackControllerRequest) error {
+	index, err := p.Index(req.Context.AudioPlayer.Token)
+	if err != nil {
+		return nil
+	}
+
+	if index > 0 {
+		index--
+	}
+
+	return p.PlayTrack(resp, index, 0)
+}
+
+// Pause stops playback and saves the progress of the user.
+func (p *Playlist) Pause(resp alexa.AudioPlayerStopperQueueClearer, req *alexa.PlaybackControllerRequest) error {
+	resp.StopAudio()
+	return p.save(req.Context.System.User.ID, req.Context.AudioPlayer.Token, req.Context.AudioPlayer.OffsetInMilliseconds)
+}
+
+// Resume resumes playback from the saved progress of the user.
+func (p *Playlist) Resume(resp alexa.AudioPlayerStopperQueueClearer, req *alexa.PlaybackControllerRequest) error {
+	return p.Play(resp, req.Context.System.User.ID)
+}
+
+// save stores the progress of the user if the token belongs to the playlist.
+func (p *Playlist) save(userID, token string, offsetInMilliseconds int) error {
+	index, err := p.Index(token)
+	if err != nil {
+		return nil
+	}
+
+	return p.Store.Save(p.ID, userID, Progress{Index: index, OffsetInMilliseconds: offsetInMilliseconds})
+}
@@ -0,0 +1,212 @@
+package playlist_test
+
+import (
+	"testing"
+
+	"github.com/benjic/alexa"
+	"github.com/benjic/alexa/playlist"
+)
+
+const userID = "amzn1.ask.account.TEST"
+
+// player records the directives a playlist adds to a response.
+type player struct {
+	behavior string
+	play     *alexa.PlayRequest
+	stopped  bool
+}
+
+func (p *player) ReplaceAllAudio(r alexa.PlayRequest) {
+	p.behavior, p.play = "REPLACE_ALL", &r
+}
+
+func (p *player) EnqueueAudio(r alexa.PlayRequest) {
+	p.behavior, p.play = "ENQUEUE", &r
+}
+
+func (p *player) ReplaceEnqueuedAudio(r alexa.PlayRequest) {
+	p.behavior, p.play = "REPLACE_ENQUEUED", &r
+}
+
+func (p *player) StopAudio()          { p.stopped = true }
+func (p *player) ClearEnqueuedAudio() {}
+func (p *player) ClearAllAudio()      {}
+
+func newPlaylist() *playlist.Playlist {
+	return &playlist.Playlist{
+		ID: "podcast",
+		Tracks: []playlist.Track{
+			{URL: "https://example.com/0.mp3"},
+			{URL: "https://example.com/1.mp3"},
+			{URL: "https://example.com/2.mp3"},
+		},
+		Store: playlist.NewMemoryStore(),
+	}
+}
+
+func audioPlaybackRequest(token string, offset int) *alexa.AudioPlaybackRequest {
+	req := &alexa.AudioPlaybackRequest{}
+	req.Context.System.User.ID = userID
+	req.Request.Token = token
+	req.Request.OffsetInMilliseconds = offset
+	return req
+}
+
+func playbackControllerRequest(token string, offset int) *alexa.PlaybackControllerRequest {
+	req := &alexa.PlaybackControllerRequest{}
+	req.Context.System.User.ID = userID
+	req.Context.AudioPlayer.Token = token
+	req.Context.AudioPlayer.OffsetInMilliseconds = offset
+	return req
+}
+
+func TestIndex(t *testing.T) {
+	p := newPlaylist()
+
+	cases := []struct {
+		token string
+		index int
+		err   error
+	}{
+		{"podcast:0", 0, nil},
+		{"podcast:2", 2, nil},
+		{"podcast:3", 0, playlist.ErrUnknownToken},
+		{"podcast:-1", 0, playlist.ErrUnknownToken},
+		{"podcast:x", 0, playlist.ErrUnknownToken},
+		{"music:1", 0, playlist.ErrUnknownToken},
+		{"", 0, playlist.ErrUnknownToken},
+	}
+
+	for _, c := range cases {
+		index, err := p.Index(c.token)
+		if index != c.index || err != c.err {
+			t.Errorf("Wanted %d, %v; got %d, %v for %q", c.index, c.err, index, err, c.token)
+		}
+	}
+}
+
+func TestPlaybackNearlyFinished(t *testing.T) {
+	p := newPlaylist()
+
+	resp := &player{}
+	if err := p.PlaybackNearlyFinished(resp, audioPlaybackRequest("podcast:0", 1000)); err != nil {
+		t.Fatalf("Did not want err; got %s", err)
+	}
+
+	if resp.behavior != "ENQUEUE" {
+		t.Fatalf("Wanted ENQUEUE; got %q", resp.behavior)
+	}
+	if resp.play.Token != "podcast:1" || resp.play.ExpectedPreviousToken != "podcast:0" || resp.play.URL != "https://example.com/1.mp3" {
+		t.Errorf("Unexpected play request %+v", resp.play)
+	}
+
+	resp = &player{}
+	p.PlaybackNearlyFinished(resp, audioPlaybackRequest("podcast:2", 1000))
+	if resp.play != nil {
+		t.Errorf("Did not want the last track to enqueue; got %+v", resp.play)
+	}
+}
+
+func TestNextPrevious(t *testing.T) {
+	p := newPlaylist()
+
+	resp := &player{}
+	p.Next(resp, playbackControllerRequest("podcast:1", 5000))
+	if resp.behavior != "REPLACE_ALL" || resp.play.Token != "podcast:2" || resp.play.OffsetInMilliseconds != 0 {
+		t.Errorf("Unexpected next play request %s %+v", resp.behavior, resp.play)
+	}
+
+	resp = &player{}
+	p.Next(resp, playbackControllerRequest("podcast:2", 5000))
+	if !resp.stopped || resp.play != nil {
+		t.Errorf("Wanted next on the last track to stop; got %+v", resp)
+	}
+
+	resp = &player{}
+	p.Previous(resp, playbackControllerRequest("podcast:1", 5000))
+	if resp.play.Token != "podcast:0" {
+		t.Errorf("Wanted podcast:0; got %s", resp.play.Token)
+	}
+
+	resp = &player{}
+	p.Previous(resp, playbackControllerRequest("podcast:0", 5000))
+	if resp.play.Token != "podcast:0" {
+		t.Errorf("Wanted podcast:0; got %s", resp.play.Token)
+	}
+}
+
+func TestResumeAtOffset(t *testing.T) {
+	p := newPlaylist()
+
+	resp := &player{}
+	if err := p.Play(resp, userID); err != nil {
+		t.Fatalf("Did not want err; got %s", err)
+	}
+	if resp.play.Token != "podcast:0" || resp.play.OffsetInMilliseconds != 0 {
+		t.Errorf("Wanted to start at the first track; got %+v", resp.play)
+	}
+
+	p.PlaybackStarted(&player{}, audioPlaybackRequest("podcast:1", 0))
+	p.PlaybackStopped(audioPlaybackRequest("podcast:1", 4200))
+
+	resp = &player{}
+	p.Resume(resp, playbackControllerRequest("", 0))
+	if resp.play.Token != "podcast:1" || resp.play.OffsetInMilliseconds != 4200 {
+		t.Errorf("Wanted to resume podcast:1 at 4200; got %+v", resp.play)
+	}
+
+	p.PlaybackFinished(&player{}, audioPlaybackRequest("podcast:2", 0))
+	progress, _ := p.Store.Load(p.ID, userID)
+	if progress != (playlist.Progress{}) {
+		t.Errorf("Wanted progress to reset after the last track; got %+v", progress)
+	}
+}
+
+func TestSharedStore(t *testing.T) {
+	podcast := newPlaylist()
+	audiobook := newPlaylist()
+	audiobook.ID = "audiobook"
+	audiobook.Store = podcast.Store
+
+	podcast.PlaybackStopped(audioPlaybackRequest("podcast:2", 1500))
+	audiobook.PlaybackStopped(audioPlaybackRequest("audiobook:1", 300))
+
+	resp := &player{}
+	podcast.Resume(resp, playbackControllerRequest("", 0))
+	if resp.play.Token != "podcast:2" || resp.play.OffsetInMilliseconds != 1500 {
+		t.Errorf("Wanted to resume podcast:2 at 1500; got %+v", resp.play)
+	}
+
+	resp = &player{}
+	audiobook.Resume(resp, playbackControllerRequest("", 0))
+	if resp.play.Token != "audiobook:1" || resp.play.OffsetInMilliseconds != 300 {
+		t.Errorf("Wanted to resume audiobook:1 at 300; got %+v", resp.play)
+	}
+}
+
+func TestEmptyPlaylist(t *testing.T) {
+	p := &playlist.Playlist{ID: "empty", Store: &playlist.MemoryStore{}}
+
+	resp := &player{}
+	if err := p.Play(resp, userID); err != playlist.ErrNoTrack {
+		t.Errorf("Wanted %s; got %v", playlist.ErrNoTrack, err)
+	}
+	if err := p.PlayTrack(resp, 0, 0); err != playlist.ErrNoTrack {
+		t.Errorf("Wanted %s; got %v", playlist.ErrNoTrack, err)
+	}
+	if err := p.PlaybackNearlyFinished(resp, audioPlaybackRequest("empty:0", 0)); err != nil {
+		t.Errorf("Did not want err; got %s", err)
+	}
+	if resp.play != nil {
+		t.Errorf("Did not want audio to play; got %+v", resp.play)
+	}
+}
+
+func TestInstall(t *testing.T) {
+	h := &alexa.Handler{}
+	newPlaylist().Install(h)
+
+	if h.AudioPlaybackNearlyFinishedRequest == nil || h.PlaybackControllerNextCommandRequest == nil {
+		t.Errorf("Wanted handler functions to be installed")
+	}
+}
@@ -0,0 +1,56 @@
+package playlist
+
+import "sync"
+
+// Progress records the position of a user within a playlist.
+type Progress struct {
+	Index                int `json:"index"`
+	OffsetInMilliseconds int `json:"offsetInMilliseconds"`
+}
+
+// A Store persists the progress of users within playlists. Progress is keyed
+// by both the playlist ID and the user ID so several playlists can share a
+// Store.
+type Store interface {
+	// Load returns the saved progress of the user within the playlist. The
+	// zero Progress is returned when none has been saved.
+	Load(playlistID, userID string) (Progress, error)
+	// Save replaces the saved progress of the user within the playlist.
+	Save(playlistID, userID string, p Progress) error
+}
+
+// A MemoryStore keeps progress in memory. It is suitable for tests and skills
+// served by a single process. The zero value is an empty MemoryStore ready to
+// use.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	progress map[progressKey]Progress
+}
+
+type progressKey struct {
+	playlistID string
+	userID     string
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{progress: map[progressKey]Progress{}}
+}
+
+// Load returns the saved progress of the user within the playlist.
+func (s *MemoryStore) Load(playlistID, userID string) (Progress, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.progress[progressKey{playlistID, userID}], nil
+}
+
+// Save replaces the saved progress of the user within the playlist.
+func (s *MemoryStore) Save(playlistID, userID string, p Progress) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.progress == nil {
+		s.progress = map[progressKey]Progress{}
+	}
+	s.progress[progressKey{playlistID, userID}] = p
+	return nil
+}
@@ -0,0 +1,17 @@
+package playlist_test
+
+import (
+	"testing"
+
+	"github.com/benjic/alexa/playlist"
+)
+
+func TestMemoryStoreZeroValue(t *testing.T) {
+	s := &playlist.MemoryStore{}
+	if err := s.Save("podcast", userID, playlist.Progress{Index: 2}); err != nil {
+		t.Fatalf("Did not want err; got %s", err)
+	}
+	if progress, err := s.Load("podcast", userID); err != nil || progress.Index != 2 {
+		t.Errorf("Wanted index 2; got %+v, %v", progress, err)
+	}
+}
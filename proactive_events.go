@@ -0,0 +1,198 @@
+package alexa
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// The regional endpoints of the Alexa service APIs used outside of a request
+// to a skill.
+const (
+	NorthAmericaAPIEndpoint = "https://api.amazonalexa.com"
+	EuropeAPIEndpoint       = "https://api.eu.amazonalexa.com"
+	FarEastAPIEndpoint      = "https://api.fe.amazonalexa.com"
+)
+
+const (
+	// ProactiveEventsScope is the Login with Amazon scope of tokens used to
+	// send proactive events.
+	ProactiveEventsScope = "alexa::proactive_events"
+
+	proactiveEventsLivePath        = "/v1/proactiveEvents"
+	proactiveEventsDevelopmentPath = "/v1/proactiveEvents/stages/development"
+
+	unicastAudienceType   = "Unicast"
+	multicastAudienceType = "Multicast"
+)
+
+// A ProactiveEventsStage selects whether events are delivered to the
+// development or live version of a skill.
+type ProactiveEventsStage int
+
+// The stages a proactive event can be sent to.
+const (
+	DevelopmentStage ProactiveEventsStage = iota
+	LiveStage
+)
+
+// An EventSchema is the payload of a proactive event. Each schema is defined
+// by Amazon.
+//
+// https://developer.amazon.com/docs/smapi/schemas-for-proactive-events.html
+type EventSchema interface {
+	// SchemaName returns the name of the schema such as
+	// AMAZON.WeatherAlert.Activated.
+	SchemaName() string
+}
+
+// OrderStatusUpdated notifies the customer of a change to an order.
+type OrderStatusUpdated struct {
+	State struct {
+		// Status is one of PREORDER_RECEIVED, ORDER_RECEIVED,
+		// ORDER_PREPARING, ORDER_SHIPPED, ORDER_OUT_FOR_DELIVERY or
+		// ORDER_DELIVERED.
+		Status          string `json:"status"`
+		DeliveryDetails *struct {
+			ExpectedArrival string `json:"expectedArrival,omitempty"`
+			DeliveredTime   string `json:"deliveredTime,omitempty"`
+		} `json:"deliveryDetails,omitempty"`
+	} `json:"state"`
+	Order struct {
+		Seller struct {
+			Name string `json:"name"`
+		} `json:"seller"`
+	} `json:"order"`
+}
+
+// SchemaName returns AMAZON.OrderStatus.Updated.
+func (OrderStatusUpdated) SchemaName() string { return "AMAZON.OrderStatus.Updated" }
+
+// WeatherAlertActivated notifies the customer of a weather alert.
+type WeatherAlertActivated struct {
+	WeatherAlert struct {
+		Source string `json:"source"`
+		// AlertType is one of DEFAULT, SEVERE_THUNDERSTORM, HURRICANE,
+		// TORNADO, SNOW_STORM, WINTER_STORM, FLOOD, TSUNAMI or others defined
+		// by the schema.
+		AlertType string `json:"alertType"`
+	} `json:"weatherAlert"`
+}
+
+// SchemaName returns AMAZON.WeatherAlert.Activated.
+func (WeatherAlertActivated) SchemaName() string { return "AMAZON.WeatherAlert.Activated" }
+
+// MessageAlertActivated notifies the customer of messages waiting for them.
+type MessageAlertActivated struct {
+	State struct {
+		Status    string `json:"status"`
+		Freshness string `json:"freshness,omitempty"`
+	} `json:"state"`
+	MessageGroup struct {
+		Creator struct {
+			Name string `json:"name"`
+		} `json:"creator"`
+		Count   int    `json:"count"`
+		Urgency string `json:"urgency,omitempty"`
+	} `json:"messageGroup"`
+}
+
+// SchemaName returns AMAZON.MessageAlert.Activated.
+func (MessageAlertActivated) SchemaName() string { return "AMAZON.MessageAlert.Activated" }
+
+// An Audience selects the customers a proactive event is delivered to.
+type Audience struct {
+	Type    string `json:"type"`
+	Payload struct {
+		User string `json:"user,omitempty"`
+	} `json:"payload"`
+}
+
+// UnicastAudience delivers an event to a single customer.
+func UnicastAudience(userID string) Audience {
+	a := Audience{Type: unicastAudienceType}
+	a.Payload.User = userID
+	return a
+}
+
+// MulticastAudience delivers an event to every customer subscribed to the
+// schema.
+func MulticastAudience() Audience {
+	return Audience{Type: multicastAudienceType}
+}
+
+// A ProactiveEvent is a notification sent to customers outside of a request
+// to the skill.
+type ProactiveEvent struct {
+	Timestamp   string `json:"timestamp"`
+	ReferenceID string `json:"referenceId"`
+	ExpiryTime  string `json:"expiryTime"`
+	Event       struct {
+		Name    string      `json:"name"`
+		Payload EventSchema `json:"payload"`
+	} `json:"event"`
+	LocalizedAttributes []map[string]string `json:"localizedAttributes"`
+	RelevantAudience    Audience            `json:"relevantAudience"`
+}
+
+// NewProactiveEvent returns an event with the schema for the audience. The
+// referenceID must be unique to the event and the event is discarded if it is
+// not delivered before the expiry.
+func NewProactiveEvent(referenceID string, schema EventSchema, audience Audience, expiry time.Time) *ProactiveEvent {
+	e := &ProactiveEvent{
+		Timestamp:           time.Now().UTC().Format(time.RFC3339),
+		ReferenceID:         referenceID,
+		ExpiryTime:          expiry.UTC().Format(time.RFC3339),
+		LocalizedAttributes: []map[string]string{},
+		RelevantAudience:    audience,
+	}
+	e.Event.Name = schema.SchemaName()
+	e.Event.Payload = schema
+	return e
+}
+
+// Localize adds the values of the localizedattribute references in the schema
+// for the locale.
+func (e *ProactiveEvent) Localize(locale string, attributes map[string]string) *ProactiveEvent {
+	attrs := map[string]string{"locale": locale}
+	for k, v := range attributes {
+		attrs[k] = v
+	}
+	e.LocalizedAttributes = append(e.LocalizedAttributes, attrs)
+	return e
+}
+
+// A ProactiveEventsClient sends proactive events with the Proactive Events
+// API. Access tokens are obtained from Tokens for every event sent.
+//
+// https://developer.amazon.com/docs/smapi/proactive-events-api.html
+type ProactiveEventsClient struct {
+	*ServiceClient
+	Tokens TokenSource
+	Stage  ProactiveEventsStage
+}
+
+// NewProactiveEventsClient returns a ProactiveEventsClient sending events to
+// the stage through the regional apiEndpoint, such as NorthAmericaAPIEndpoint.
+func NewProactiveEventsClient(apiEndpoint string, tokens TokenSource, stage ProactiveEventsStage) *ProactiveEventsClient {
+	return &ProactiveEventsClient{
+		ServiceClient: NewServiceClient(apiEndpoint, ""),
+		Tokens:        tokens,
+		Stage:         stage,
+	}
+}
+
+// Send delivers the event to its audience.
+func (c *ProactiveEventsClient) Send(ctx context.Context, e *ProactiveEvent) error {
+	sc, err := authorize(ctx, c.ServiceClient, c.Tokens)
+	if err != nil {
+		return err
+	}
+
+	path := proactiveEventsDevelopmentPath
+	if c.Stage == LiveStage {
+		path = proactiveEventsLivePath
+	}
+
+	return sc.Do(ctx, http.MethodPost, path, e, nil)
+}
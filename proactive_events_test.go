@@ -0,0 +1,67 @@
+package alexa_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/benjic/alexa"
+)
+
+func TestProactiveEventsClientSend(t *testing.T) {
+	issued := 0
+	lwa := newLWAServer(t, 3600, &issued)
+	defer lwa.Close()
+
+	var paths []string
+	var got map[string]interface{}
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer token" {
+			t.Errorf("Wanted bearer token; got %q", auth)
+		}
+		paths = append(paths, r.URL.Path)
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer api.Close()
+
+	tokens := alexa.NewLWATokenSource("id", "secret", alexa.ProactiveEventsScope)
+	tokens.TokenURL = lwa.URL
+
+	alert := alexa.WeatherAlertActivated{}
+	alert.WeatherAlert.Source = "localizedattribute:source"
+	alert.WeatherAlert.AlertType = "TORNADO"
+
+	e := alexa.NewProactiveEvent("ref-1", alert, alexa.UnicastAudience("amzn1.ask.account.TEST"), time.Now().Add(time.Hour)).
+		Localize("en-US", map[string]string{"source": "Weather Service"})
+
+	c := alexa.NewProactiveEventsClient(api.URL, tokens, alexa.DevelopmentStage)
+	if err := c.Send(context.Background(), e); err != nil {
+		t.Fatalf("Did not want err; got %s", err)
+	}
+
+	c.Stage = alexa.LiveStage
+	if err := c.Send(context.Background(), e); err != nil {
+		t.Fatalf("Did not want err; got %s", err)
+	}
+
+	if len(paths) != 2 || paths[0] != "/v1/proactiveEvents/stages/development" || paths[1] != "/v1/proactiveEvents" {
+		t.Errorf("Unexpected paths %v", paths)
+	}
+	if issued != 1 {
+		t.Errorf("Wanted a single token issued; got %d", issued)
+	}
+
+	event := got["event"].(map[string]interface{})
+	payload := event["payload"].(map[string]interface{})["weatherAlert"].(map[string]interface{})
+	if event["name"] != "AMAZON.WeatherAlert.Activated" || payload["alertType"] != "TORNADO" {
+		t.Errorf("Unexpected event %+v", event)
+	}
+	audience := got["relevantAudience"].(map[string]interface{})
+	if audience["type"] != "Unicast" {
+		t.Errorf("Unexpected audience %+v", audience)
+	}
+}
@@ -0,0 +1,47 @@
+package alexa
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	directivesPath       = "/v1/directives"
+	voicePlayerSpeakType = "VoicePlayer.Speak"
+)
+
+// A DirectiveClient sends progressive responses to the Alexa Directive
+// Service. A progressive response is spoken to the user while a skill is still
+// preparing the full response to a LaunchRequest or IntentRequest.
+//
+// https://developer.amazon.com/docs/custom-skills/send-the-user-a-progressive-response.html
+type DirectiveClient struct {
+	*ServiceClient
+}
+
+// NewDirectiveClient returns a DirectiveClient for the apiEndpoint and
+// apiAccessToken provided in the context of a request.
+func NewDirectiveClient(apiEndpoint, apiAccessToken string) *DirectiveClient {
+	return &DirectiveClient{NewServiceClient(apiEndpoint, apiAccessToken)}
+}
+
+type directiveRequest struct {
+	Header struct {
+		RequestID string `json:"requestId"`
+	} `json:"header"`
+	Directive struct {
+		Type   string `json:"type"`
+		Speech string `json:"speech"`
+	} `json:"directive"`
+}
+
+// Speak plays the speech to the user while the request with the given
+// requestID is handled. The speech may be plain text or SSML.
+func (c *DirectiveClient) Speak(ctx context.Context, requestID, speech string) error {
+	d := directiveRequest{}
+	d.Header.RequestID = requestID
+	d.Directive.Type = voicePlayerSpeakType
+	d.Directive.Speech = speech
+
+	return c.Do(ctx, http.MethodPost, directivesPath, d, nil)
+}
@@ -0,0 +1,64 @@
+package alexa_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/benjic/alexa"
+)
+
+func TestDirectiveClientSpeak(t *testing.T) {
+	var got struct {
+		Header struct {
+			RequestID string `json:"requestId"`
+		} `json:"header"`
+		Directive struct {
+			Type   string `json:"type"`
+			Speech string `json:"speech"`
+		} `json:"directive"`
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/directives" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer token" {
+			t.Errorf("Wanted bearer token; got %q", auth)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("Failed to decode body: %s", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := alexa.NewDirectiveClient(srv.URL, "token")
+	if err := c.Speak(context.Background(), "request-id", "One moment please"); err != nil {
+		t.Fatalf("Did not want err; got %s", err)
+	}
+
+	if got.Header.RequestID != "request-id" || got.Directive.Type != "VoicePlayer.Speak" || got.Directive.Speech != "One moment please" {
+		t.Errorf("Unexpected directive %+v", got)
+	}
+}
+
+func TestDirectiveClientSpeakErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	c := alexa.NewDirectiveClient(srv.URL, "token")
+	if err := c.Speak(context.Background(), "request-id", "speech"); err == nil {
+		t.Errorf("Wanted err for unauthorized response")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.Speak(ctx, "request-id", "speech"); err == nil {
+		t.Errorf("Wanted err for cancelled context")
+	}
+}
@@ -0,0 +1,238 @@
+package alexa
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	remindersPath = "/v1/alerts/reminders"
+
+	// reminderTimeLayout is the layout of reminder times. Times do not carry an
+	// offset as the time zone is given separately.
+	reminderTimeLayout = "2006-01-02T15:04:05.000"
+
+	scheduledAbsoluteTriggerType = "SCHEDULED_ABSOLUTE"
+	scheduledRelativeTriggerType = "SCHEDULED_RELATIVE"
+	pushNotificationEnabled      = "ENABLED"
+	pushNotificationDisabled     = "DISABLED"
+)
+
+// A Reminder is an alert Alexa speaks to the customer at a scheduled time.
+// Reminders are built with NewAbsoluteReminder or NewRelativeReminder.
+//
+// https://developer.amazon.com/docs/smapi/alexa-reminders-api-reference.html
+type Reminder struct {
+	RequestTime      string                   `json:"requestTime"`
+	Trigger          ReminderTrigger          `json:"trigger"`
+	AlertInfo        ReminderAlertInfo        `json:"alertInfo"`
+	PushNotification ReminderPushNotification `json:"pushNotification"`
+
+	// The following are set by Alexa when a reminder is read.
+
+	AlertToken  string `json:"alertToken,omitempty"`
+	CreatedTime string `json:"createdTime,omitempty"`
+	UpdatedTime string `json:"updatedTime,omitempty"`
+	Status      string `json:"status,omitempty"`
+	Version     string `json:"version,omitempty"`
+}
+
+// A ReminderTrigger schedules when a Reminder is spoken.
+type ReminderTrigger struct {
+	Type            string              `json:"type"`
+	ScheduledTime   string              `json:"scheduledTime,omitempty"`
+	OffsetInSeconds int                 `json:"offsetInSeconds,omitempty"`
+	TimeZoneID      string              `json:"timeZoneId,omitempty"`
+	Recurrence      *ReminderRecurrence `json:"recurrence,omitempty"`
+}
+
+// A ReminderRecurrence repeats a Reminder according to iCalendar RRULE rules
+// such as those returned by DailyRecurrenceRule and WeeklyRecurrenceRule.
+type ReminderRecurrence struct {
+	StartDateTime   string   `json:"startDateTime,omitempty"`
+	EndDateTime     string   `json:"endDateTime,omitempty"`
+	RecurrenceRules []string `json:"recurrenceRules,omitempty"`
+}
+
+// ReminderAlertInfo holds what is spoken when a Reminder is triggered.
+type ReminderAlertInfo struct {
+	SpokenInfo struct {
+		Content []ReminderContent `json:"content"`
+	} `json:"spokenInfo"`
+}
+
+// ReminderContent is the text spoken for a Reminder in a single locale.
+type ReminderContent struct {
+	Locale string `json:"locale"`
+	Text   string `json:"text,omitempty"`
+	SSML   string `json:"ssml,omitempty"`
+}
+
+// A ReminderPushNotification controls whether a Reminder is also sent to the
+// Alexa app.
+type ReminderPushNotification struct {
+	Status string `json:"status"`
+}
+
+// NewAbsoluteReminder returns a Reminder scheduled for the time t in the time
+// zone of its location. The location should be one loaded by name, such as
+// with time.LoadLocation, so it can be sent as an IANA time zone.
+func NewAbsoluteReminder(t time.Time) *Reminder {
+	r := newReminder()
+	r.Trigger = ReminderTrigger{
+		Type:          scheduledAbsoluteTriggerType,
+		ScheduledTime: t.Format(reminderTimeLayout),
+		TimeZoneID:    t.Location().String(),
+	}
+	return r
+}
+
+// NewRelativeReminder returns a Reminder scheduled for d after it is created.
+func NewRelativeReminder(d time.Duration) *Reminder {
+	r := newReminder()
+	r.Trigger = ReminderTrigger{
+		Type:            scheduledRelativeTriggerType,
+		OffsetInSeconds: int(d / time.Second),
+	}
+	return r
+}
+
+func newReminder() *Reminder {
+	return &Reminder{
+		RequestTime:      time.Now().UTC().Format(reminderTimeLayout),
+		PushNotification: ReminderPushNotification{Status: pushNotificationEnabled},
+	}
+}
+
+// Say adds the text spoken for the locale.
+func (r *Reminder) Say(locale, text string) *Reminder {
+	r.AlertInfo.SpokenInfo.Content = append(r.AlertInfo.SpokenInfo.Content, ReminderContent{
+		Locale: locale,
+		Text:   text,
+	})
+	return r
+}
+
+// SaySSML adds the SSML spoken for the locale along with a plain text version
+// shown in the Alexa app.
+func (r *Reminder) SaySSML(locale, ssml, text string) *Reminder {
+	r.AlertInfo.SpokenInfo.Content = append(r.AlertInfo.SpokenInfo.Content, ReminderContent{
+		Locale: locale,
+		SSML:   ssml,
+		Text:   text,
+	})
+	return r
+}
+
+// Recurring repeats an absolute reminder according to the rules between the
+// start and end times. A zero end repeats the reminder indefinitely.
+func (r *Reminder) Recurring(start, end time.Time, rules ...string) *Reminder {
+	rec := &ReminderRecurrence{
+		StartDateTime:   start.Format(reminderTimeLayout),
+		RecurrenceRules: rules,
+	}
+	if !end.IsZero() {
+		rec.EndDateTime = end.Format(reminderTimeLayout)
+	}
+	r.Trigger.Recurrence = rec
+	return r
+}
+
+// PushNotifications controls whether the reminder is also sent to the Alexa
+// app. Push notifications are enabled by default.
+func (r *Reminder) PushNotifications(enabled bool) *Reminder {
+	r.PushNotification.Status = pushNotificationDisabled
+	if enabled {
+		r.PushNotification.Status = pushNotificationEnabled
+	}
+	return r
+}
+
+// DailyRecurrenceRule returns a rule repeating a reminder every day at the
+// given time.
+func DailyRecurrenceRule(hour, minute int) string {
+	return fmt.Sprintf("FREQ=DAILY;BYHOUR=%d;BYMINUTE=%d;BYSECOND=0", hour, minute)
+}
+
+// WeeklyRecurrenceRule returns a rule repeating a reminder on the given days
+// of every week at the given time.
+func WeeklyRecurrenceRule(hour, minute int, days ...time.Weekday) string {
+	ds := make([]string, len(days))
+	for i, d := range days {
+		ds[i] = strings.ToUpper(d.String()[:2])
+	}
+	return fmt.Sprintf("FREQ=WEEKLY;BYDAY=%s;BYHOUR=%d;BYMINUTE=%d;BYSECOND=0", strings.Join(ds, ","), hour, minute)
+}
+
+// A RemindersClient manages the reminders of a customer with the Reminders
+// API. The customer must grant the RemindersPermission.
+type RemindersClient struct {
+	*ServiceClient
+}
+
+// NewRemindersClient returns a RemindersClient for the apiEndpoint and
+// apiAccessToken provided in the context of a request.
+func NewRemindersClient(apiEndpoint, apiAccessToken string) *RemindersClient {
+	return &RemindersClient{NewServiceClient(apiEndpoint, apiAccessToken)}
+}
+
+// Create schedules the reminder and returns it with the fields set by Alexa.
+func (c *RemindersClient) Create(ctx context.Context, r *Reminder) (*Reminder, error) {
+	created := &Reminder{}
+	if err := c.do(ctx, http.MethodPost, remindersPath, r, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// Get returns the reminder identified by the alertToken.
+func (c *RemindersClient) Get(ctx context.Context, alertToken string) (*Reminder, error) {
+	r := &Reminder{}
+	if err := c.do(ctx, http.MethodGet, reminderPath(alertToken), nil, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Update replaces the reminder identified by the alertToken.
+func (c *RemindersClient) Update(ctx context.Context, alertToken string, r *Reminder) (*Reminder, error) {
+	updated := &Reminder{}
+	if err := c.do(ctx, http.MethodPut, reminderPath(alertToken), r, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Delete removes the reminder identified by the alertToken.
+func (c *RemindersClient) Delete(ctx context.Context, alertToken string) error {
+	return c.do(ctx, http.MethodDelete, reminderPath(alertToken), nil, nil)
+}
+
+// List returns every reminder the skill has created for the customer.
+func (c *RemindersClient) List(ctx context.Context) ([]Reminder, error) {
+	var list struct {
+		Alerts []Reminder `json:"alerts"`
+	}
+	if err := c.do(ctx, http.MethodGet, remindersPath, nil, &list); err != nil {
+		return nil, err
+	}
+	return list.Alerts, nil
+}
+
+// do sends a request to the Reminders API. The API responds unauthorized when
+// the customer has not granted the RemindersPermission.
+func (c *RemindersClient) do(ctx context.Context, method, path string, in, out interface{}) error {
+	err := c.Do(ctx, method, path, in, out)
+	if serr, ok := err.(*ServiceError); ok && serr.StatusCode == http.StatusUnauthorized {
+		return &PermissionError{Permissions: []Permission{RemindersPermission}}
+	}
+	return permissionError(err, RemindersPermission)
+}
+
+func reminderPath(alertToken string) string {
+	return remindersPath + "/" + url.PathEscape(alertToken)
+}
@@ -0,0 +1,109 @@
+package alexa_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/benjic/alexa"
+)
+
+func TestAbsoluteReminder(t *testing.T) {
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skipf("Time zone database unavailable: %s", err)
+	}
+
+	r := alexa.NewAbsoluteReminder(time.Date(2026, 10, 20, 8, 0, 0, 0, loc)).
+		Say("en-US", "Take your medication").
+		Say("de-DE", "Nimm deine Medikamente").
+		Recurring(time.Date(2026, 10, 20, 0, 0, 0, 0, loc), time.Time{},
+			alexa.WeeklyRecurrenceRule(8, 0, time.Monday, time.Wednesday))
+
+	bs, err := json.Marshal(r.Trigger)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %s", err)
+	}
+
+	var got, want interface{}
+	json.Unmarshal(bs, &got)
+	json.Unmarshal([]byte(`{
+		"type": "SCHEDULED_ABSOLUTE",
+		"scheduledTime": "2026-10-20T08:00:00.000",
+		"timeZoneId": "America/Los_Angeles",
+		"recurrence": {
+			"startDateTime": "2026-10-20T00:00:00.000",
+			"recurrenceRules": ["FREQ=WEEKLY;BYDAY=MO,WE;BYHOUR=8;BYMINUTE=0;BYSECOND=0"]
+		}
+	}`), &want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected trigger %s", bs)
+	}
+
+	if n := len(r.AlertInfo.SpokenInfo.Content); n != 2 {
+		t.Errorf("Wanted 2 locales; got %d", n)
+	}
+	if r.PushNotification.Status != "ENABLED" {
+		t.Errorf("Wanted push notifications enabled; got %s", r.PushNotification.Status)
+	}
+}
+
+func TestRelativeReminder(t *testing.T) {
+	r := alexa.NewRelativeReminder(90 * time.Minute).PushNotifications(false)
+	if r.Trigger.Type != "SCHEDULED_RELATIVE" || r.Trigger.OffsetInSeconds != 5400 {
+		t.Errorf("Unexpected trigger %+v", r.Trigger)
+	}
+	if r.PushNotification.Status != "DISABLED" {
+		t.Errorf("Wanted push notifications disabled; got %s", r.PushNotification.Status)
+	}
+}
+
+func TestRemindersClient(t *testing.T) {
+	var created alexa.Reminder
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/alerts/reminders":
+			json.NewDecoder(r.Body).Decode(&created)
+			w.Write([]byte(`{"alertToken": "alert-token", "status": "ON", "version": "1"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/alerts/reminders":
+			w.Write([]byte(`{"totalCount": "1", "alerts": [{"alertToken": "alert-token", "status": "ON"}]}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/alerts/reminders/alert-token":
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/alerts/reminders/missing":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := alexa.NewRemindersClient(srv.URL, "token")
+	ctx := context.Background()
+
+	r, err := c.Create(ctx, alexa.NewRelativeReminder(time.Hour).Say("en-US", "Stretch"))
+	if err != nil {
+		t.Fatalf("Did not want err; got %s", err)
+	}
+	if r.AlertToken != "alert-token" || created.AlertInfo.SpokenInfo.Content[0].Text != "Stretch" {
+		t.Errorf("Unexpected reminder %+v sent as %+v", r, created)
+	}
+
+	rs, err := c.List(ctx)
+	if err != nil || len(rs) != 1 || rs[0].AlertToken != "alert-token" {
+		t.Errorf("Unexpected reminders %+v, %v", rs, err)
+	}
+
+	if err := c.Delete(ctx, "alert-token"); err != nil {
+		t.Errorf("Did not want err; got %s", err)
+	}
+
+	if _, err := c.Get(ctx, "missing"); err == nil {
+		t.Errorf("Wanted err")
+	} else if _, ok := err.(*alexa.PermissionError); !ok {
+		t.Errorf("Wanted *PermissionError; got %T", err)
+	}
+}
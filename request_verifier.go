@@ -20,6 +20,10 @@ const (
 	signatureHeader             = "Signature"
 )
 
+// verify is the check ServeHTTP applies to every request. Tests replace it to
+// exercise the handler without signed requests.
+var verify = verifyRequest
+
 // verifyRequest takes an Alexa request body and ensures it meets the conditions
 // specified in the amazon documentation for request verification.
 //
@@ -1,21 +1,62 @@
 package alexa
 
+import "sort"
+
+// A RequestType identifies the kind of request made by the Alexa service.
+type RequestType string
+
+// The request types a Handler is able to route.
 const (
-	audioPlayerPlaybackStartedType              = "AudioPlayer.PlaybackStarted"
-	audioPlayerPlaybackFinishedType             = "AudioPlayer.PlaybackFinished"
-	audioPlayerPlaybackStoppedType              = "AudioPlayer.PlaybackStopped"
-	audioPlayerPlaybackNearlyFinishedType       = "AudioPlayer.PlaybackNearlyFinshed"
-	audioPlayerPlaybackFailedType               = "AudioPlayer.PlaybackFailed"
-	intentRequestType                           = "IntentRequest"
-	launchRequestType                           = "LaunchRequest"
-	playbackControllerNextCommandIssuedType     = "PlaybackController.NextCommandIssued"
-	playbackControllerPausedCommandIssuedType   = "PlaybackController.PausedCommandIssued"
-	playbackControllerPlayCommandIssuedType     = "PlaybackController.PlayCommandIssued"
-	playbackControllerPreviousCommandIssuedType = "PlaybackController.PreviousCommandIssued"
-	sessionEndedRequestType                     = "SessionEndedRequest"
-	systemExceptionEncounteredType              = "System.ExceptionEncountered"
+	AudioPlayerPlaybackFailedType               RequestType = "AudioPlayer.PlaybackFailed"
+	AudioPlayerPlaybackFinishedType             RequestType = "AudioPlayer.PlaybackFinished"
+	AudioPlayerPlaybackNearlyFinishedType       RequestType = "AudioPlayer.PlaybackNearlyFinished"
+	AudioPlayerPlaybackStartedType              RequestType = "AudioPlayer.PlaybackStarted"
+	AudioPlayerPlaybackStoppedType              RequestType = "AudioPlayer.PlaybackStopped"
+	ConnectionsResponseType                     RequestType = "Connections.Response"
+	HouseholdListItemsCreatedType               RequestType = "AlexaHouseholdListEvent.ItemsCreated"
+	HouseholdListItemsDeletedType               RequestType = "AlexaHouseholdListEvent.ItemsDeleted"
+	HouseholdListItemsUpdatedType               RequestType = "AlexaHouseholdListEvent.ItemsUpdated"
+	IntentRequestType                           RequestType = "IntentRequest"
+	LaunchRequestType                           RequestType = "LaunchRequest"
+	MessagingMessageReceivedType                RequestType = "Messaging.MessageReceived"
+	PlaybackControllerNextCommandIssuedType     RequestType = "PlaybackController.NextCommandIssued"
+	PlaybackControllerPauseCommandIssuedType    RequestType = "PlaybackController.PauseCommandIssued"
+	PlaybackControllerPlayCommandIssuedType     RequestType = "PlaybackController.PlayCommandIssued"
+	PlaybackControllerPreviousCommandIssuedType RequestType = "PlaybackController.PreviousCommandIssued"
+	RemindersReminderCreatedType                RequestType = "Reminders.ReminderCreated"
+	RemindersReminderDeletedType                RequestType = "Reminders.ReminderDeleted"
+	RemindersReminderStartedType                RequestType = "Reminders.ReminderStarted"
+	RemindersReminderStatusChangedType          RequestType = "Reminders.ReminderStatusChanged"
+	RemindersReminderUpdatedType                RequestType = "Reminders.ReminderUpdated"
+	SessionEndedRequestType                     RequestType = "SessionEndedRequest"
+	SystemExceptionEncounteredType              RequestType = "System.ExceptionEncountered"
 )
 
+// KnownRequestTypes returns every request type a Handler is able to route in
+// lexical order.
+func KnownRequestTypes() []RequestType {
+	ts := make([]RequestType, 0, len(routes))
+	for t := range routes {
+		ts = append(ts, t)
+	}
+	sort.Slice(ts, func(i, j int) bool { return ts[i] < ts[j] })
+	return ts
+}
+
+// Known reports whether a Handler is able to route the request type.
+func (t RequestType) Known() bool {
+	_, ok := routes[t]
+	return ok
+}
+
+// A PlaybackState describes the audio stream playing on a device when a
+// request was made.
+type PlaybackState struct {
+	Token                string `json:"token"`
+	OffsetInMilliseconds int    `json:"offsetInMilliseconds"`
+	PlayerActivity       string `json:"playerActivity"`
+}
+
 // An AudioStopperQueueClearerHandler is a function that responds to any audio
 // request where stopping and queue changes are allowed.
 type AudioStopperQueueClearerHandler func(AudioStopperQueueClearer, *AudioPlaybackRequest) error
@@ -32,10 +73,26 @@ type AudioPlaybackFailedHandler func(AudioPlayerStopperQueueClearer, *AudioPlayb
 // when the playback of an audio file stops.
 type AudioPlaybackStoppedHandler func(*AudioPlaybackRequest) error
 
+// A ConnectionsResponseHandler is a function that responds to the outcome of
+// an in-skill purchase flow started by a Purchaser.
+type ConnectionsResponseHandler func(Response, *ConnectionsResponseRequest) error
+
+// A ListItemsEventHandler is a function that will receive a request payload
+// when the items of a household list change.
+type ListItemsEventHandler func(*ListItemsEventRequest) error
+
+// A MessageReceivedHandler is a function that will receive a request payload
+// when a message is sent to the skill with the Skill Messaging API.
+type MessageReceivedHandler func(*MessageReceivedRequest) error
+
 // A PlaybackControllerRequestHandler is a function that will receive a request
 // payload when the controller state updates.
 type PlaybackControllerRequestHandler func(AudioPlayerStopperQueueClearer, *PlaybackControllerRequest) error
 
+// A ReminderEventHandler is a function that will receive a request payload
+// when a reminder created by the skill changes.
+type ReminderEventHandler func(*ReminderEventRequest) error
+
 // A SessionEndedRequestHandler is a function that will receive a request
 // payload when a session is ended.
 type SessionEndedRequestHandler func(*SessionEndedRequest) error
@@ -49,17 +106,19 @@ type SystemExceptionEncounteredHandler func(*SystemExceptionEncounteredRequest)
 type AudioPlaybackFailedRequest struct {
 	Version string `json:"version"`
 	Context struct {
-		System struct {
-			APIEndpoint string `json:"apiEndpoint"`
-			Application struct {
+		AudioPlayer PlaybackState `json:"AudioPlayer"`
+		System      struct {
+			APIAccessToken string `json:"apiAccessToken"`
+			APIEndpoint    string `json:"apiEndpoint"`
+			Application    struct {
 				ID string `json:"applicationId"`
 			} `json:"application"`
-			User struct {
-				AccessToken string `json:"accessToken"`
-				ID          string `json:"userId"`
-				Permissions struct {
-					ConsentToken string `json:"consentToken"`
-				} `json:"permissions"`
+			Device Device `json:"device"`
+			Person Person `json:"person"`
+			User   struct {
+				AccessToken string      `json:"accessToken"`
+				ID          string      `json:"userId"`
+				Permissions Permissions `json:"permissions"`
 			} `json:"user"`
 		} `json:"system"`
 	} `json:"context"`
@@ -71,14 +130,10 @@ type AudioPlaybackFailedRequest struct {
 		OffsetInMilliseconds int    `json:"offsetInMilliseconds"`
 		Locale               string `json:"locale"`
 		Error                struct {
-			Type          string `json:"type"`
-			Message       string `json:"message"`
-			PlaybackState struct {
-				Token                string `json:"token"`
-				OffsetInMilliseconds int    `json:"offsetInMilliseconds"`
-				PlayerActivity       string `json:"playerActivity"`
-			} `json:"currentPlaybackState"`
+			Type    string `json:"type"`
+			Message string `json:"message"`
 		} `json:"error"`
+		CurrentPlaybackState PlaybackState `json:"currentPlaybackState"`
 	} `json:"request"`
 }
 
@@ -87,17 +142,19 @@ type AudioPlaybackFailedRequest struct {
 type AudioPlaybackRequest struct {
 	Version string `json:"version"`
 	Context struct {
-		System struct {
-			APIEndpoint string `json:"apiEndpoint"`
-			Application struct {
+		AudioPlayer PlaybackState `json:"AudioPlayer"`
+		System      struct {
+			APIAccessToken string `json:"apiAccessToken"`
+			APIEndpoint    string `json:"apiEndpoint"`
+			Application    struct {
 				ID string `json:"applicationId"`
 			} `json:"application"`
-			User struct {
-				AccessToken string `json:"accessToken"`
-				ID          string `json:"userId"`
-				Permissions struct {
-					ConsentToken string `json:"consentToken"`
-				} `json:"permissions"`
+			Device Device `json:"device"`
+			Person Person `json:"person"`
+			User   struct {
+				AccessToken string      `json:"accessToken"`
+				ID          string      `json:"userId"`
+				Permissions Permissions `json:"permissions"`
 			} `json:"user"`
 		} `json:"system"`
 	} `json:"context"`
@@ -111,22 +168,91 @@ type AudioPlaybackRequest struct {
 	} `json:"request"`
 }
 
+// A PurchaseResult is the outcome of an in-skill purchase flow.
+type PurchaseResult string
+
+// The outcomes of an in-skill purchase flow.
+const (
+	AcceptedPurchaseResult         PurchaseResult = "ACCEPTED"
+	DeclinedPurchaseResult         PurchaseResult = "DECLINED"
+	AlreadyPurchasedPurchaseResult PurchaseResult = "ALREADY_PURCHASED"
+	ErrorPurchaseResult            PurchaseResult = "ERROR"
+)
+
+// A ConnectionsResponseRequest represents the payload provided by Amazon when
+// an in-skill purchase flow started by a Purchaser is complete. The session
+// has been restarted and the skill is expected to continue where it left off
+// with the help of the token.
+type ConnectionsResponseRequest struct {
+	Version string `json:"version"`
+	Context struct {
+		AudioPlayer PlaybackState `json:"AudioPlayer"`
+		System      struct {
+			APIAccessToken string `json:"apiAccessToken"`
+			APIEndpoint    string `json:"apiEndpoint"`
+			Application    struct {
+				ID string `json:"applicationId"`
+			} `json:"application"`
+			Device Device `json:"device"`
+			Person Person `json:"person"`
+			User   struct {
+				AccessToken string      `json:"accessToken"`
+				ID          string      `json:"userId"`
+				Permissions Permissions `json:"permissions"`
+			} `json:"user"`
+		} `json:"system"`
+	} `json:"context"`
+	Session struct {
+		Application struct {
+			ID string `json:"applicationId"`
+		} `json:"application"`
+		Attributes map[string]interface{} `json:"attributes"`
+		ID         string                 `json:"sessionId"`
+		New        bool                   `json:"new"`
+		User       struct {
+			AccessToken string      `json:"accessToken"`
+			ID          string      `json:"userId"`
+			Permissions Permissions `json:"permissions"`
+		} `json:"user"`
+	} `json:"session"`
+	Request struct {
+		Type      string `json:"type"`
+		RequestID string `json:"requestId"`
+		Timestamp string `json:"timestamp"`
+		Locale    string `json:"locale"`
+		// Name is Buy, Upsell or Cancel.
+		Name   string `json:"name"`
+		Status struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"status"`
+		Payload struct {
+			PurchaseResult PurchaseResult `json:"purchaseResult"`
+			ProductID      string         `json:"productId"`
+			Message        string         `json:"message"`
+		} `json:"payload"`
+		Token string `json:"token"`
+	} `json:"request"`
+}
+
 // IntentRequest represents they payload provided by Amazon when an Alexa Intent
 // request is made.
 type IntentRequest struct {
 	Version string `json:"version"`
 	Context struct {
-		System struct {
-			APIEndpoint string `json:"apiEndpoint"`
-			Application struct {
+		AudioPlayer PlaybackState `json:"AudioPlayer"`
+		System      struct {
+			APIAccessToken string `json:"apiAccessToken"`
+			APIEndpoint    string `json:"apiEndpoint"`
+			Application    struct {
 				ID string `json:"applicationId"`
 			} `json:"application"`
-			User struct {
-				AccessToken string `json:"accessToken"`
-				ID          string `json:"userId"`
-				Permissions struct {
-					ConsentToken string `json:"consentToken"`
-				} `json:"permissions"`
+			Device Device `json:"device"`
+			Person Person `json:"person"`
+			User   struct {
+				AccessToken string      `json:"accessToken"`
+				ID          string      `json:"userId"`
+				Permissions Permissions `json:"permissions"`
 			} `json:"user"`
 		} `json:"system"`
 	} `json:"context"`
@@ -138,11 +264,9 @@ type IntentRequest struct {
 		ID         string                 `json:"sessionId"`
 		New        bool                   `json:"new"`
 		User       struct {
-			AccessToken string `json:"accessToken"`
-			ID          string `json:"userId"`
-			Permissions struct {
-				ConsentToken string `json:"consentToken"`
-			} `json:"permissions"`
+			AccessToken string      `json:"accessToken"`
+			ID          string      `json:"userId"`
+			Permissions Permissions `json:"permissions"`
 		} `json:"user"`
 	} `json:"session"`
 	Request struct {
@@ -151,27 +275,9 @@ type IntentRequest struct {
 		DialogState string `json:"dialogState"`
 		Locale      string `json:"locale"`
 		Intent      struct {
-			Name               string `json:"name"`
-			ConfirmationStatus string `json:"confirmationStatus"`
-			Slots              map[string]struct {
-				Name               string `json:"name"`
-				Value              string `json:"value"`
-				ConfirmationStatus string `json:"confirmationStatus"`
-				Resolutions        struct {
-					ResolutionsPerAuthority []struct {
-						Authority string `json:"authority"`
-						Status    struct {
-							Code string `json:"code"`
-						} `json:"status"`
-						Values []struct {
-							Value struct {
-								Name string `json:"name"`
-								ID   string `json:"id"`
-							} `json:"value"`
-						} `json:"values"`
-					} `json:"resolutionsPerAuthority"`
-				} `json:"resolutions"`
-			} `json:"slots"`
+			Name               string          `json:"name"`
+			ConfirmationStatus string          `json:"confirmationStatus"`
+			Slots              map[string]Slot `json:"slots"`
 		} `json:"intent"`
 	} `json:"request"`
 }
@@ -181,17 +287,19 @@ type IntentRequest struct {
 type LaunchRequest struct {
 	Version string `json:"version"`
 	Context struct {
-		System struct {
-			APIEndpoint string `json:"apiEndpoint"`
-			Application struct {
+		AudioPlayer PlaybackState `json:"AudioPlayer"`
+		System      struct {
+			APIAccessToken string `json:"apiAccessToken"`
+			APIEndpoint    string `json:"apiEndpoint"`
+			Application    struct {
 				ID string `json:"applicationId"`
 			} `json:"application"`
-			User struct {
-				AccessToken string `json:"accessToken"`
-				ID          string `json:"userId"`
-				Permissions struct {
-					ConsentToken string `json:"consentToken"`
-				} `json:"permissions"`
+			Device Device `json:"device"`
+			Person Person `json:"person"`
+			User   struct {
+				AccessToken string      `json:"accessToken"`
+				ID          string      `json:"userId"`
+				Permissions Permissions `json:"permissions"`
 			} `json:"user"`
 		} `json:"system"`
 	} `json:"context"`
@@ -203,11 +311,9 @@ type LaunchRequest struct {
 		ID         string                 `json:"sessionId"`
 		New        bool                   `json:"new"`
 		User       struct {
-			AccessToken string `json:"accessToken"`
-			ID          string `json:"userId"`
-			Permissions struct {
-				ConsentToken string `json:"consentToken"`
-			} `json:"permissions"`
+			AccessToken string      `json:"accessToken"`
+			ID          string      `json:"userId"`
+			Permissions Permissions `json:"permissions"`
 		} `json:"user"`
 	} `json:"session"`
 	Request struct {
@@ -217,29 +323,126 @@ type LaunchRequest struct {
 	} `json:"request"`
 }
 
+// A ListItemsEventRequest represents the payload provided by Amazon when items
+// are created, updated or deleted in a household list.
+type ListItemsEventRequest struct {
+	Version string `json:"version"`
+	Context struct {
+		System struct {
+			APIAccessToken string `json:"apiAccessToken"`
+			APIEndpoint    string `json:"apiEndpoint"`
+			Application    struct {
+				ID string `json:"applicationId"`
+			} `json:"application"`
+			Device Device `json:"device"`
+			Person Person `json:"person"`
+			User   struct {
+				AccessToken string      `json:"accessToken"`
+				ID          string      `json:"userId"`
+				Permissions Permissions `json:"permissions"`
+			} `json:"user"`
+		} `json:"system"`
+	} `json:"context"`
+	Request struct {
+		Type      string `json:"type"`
+		RequestID string `json:"requestId"`
+		Timestamp string `json:"timestamp"`
+		Locale    string `json:"locale"`
+		Body      struct {
+			ListID      string   `json:"listId"`
+			ListItemIDs []string `json:"listItemIds"`
+		} `json:"body"`
+	} `json:"request"`
+}
+
+// A MessageReceivedRequest represents the payload provided by Amazon when a
+// message is sent to the skill with the Skill Messaging API.
+type MessageReceivedRequest struct {
+	Version string `json:"version"`
+	Context struct {
+		System struct {
+			APIAccessToken string `json:"apiAccessToken"`
+			APIEndpoint    string `json:"apiEndpoint"`
+			Application    struct {
+				ID string `json:"applicationId"`
+			} `json:"application"`
+			Device Device `json:"device"`
+			Person Person `json:"person"`
+			User   struct {
+				AccessToken string      `json:"accessToken"`
+				ID          string      `json:"userId"`
+				Permissions Permissions `json:"permissions"`
+			} `json:"user"`
+		} `json:"system"`
+	} `json:"context"`
+	Request struct {
+		Type      string                 `json:"type"`
+		RequestID string                 `json:"requestId"`
+		Timestamp string                 `json:"timestamp"`
+		Locale    string                 `json:"locale"`
+		Message   map[string]interface{} `json:"message"`
+	} `json:"request"`
+}
+
 // A PlaybackControllerRequest represents the payload provided by Amazon when
 // a controller state updates.
 type PlaybackControllerRequest struct {
+	Version string `json:"version"`
+	Context struct {
+		AudioPlayer PlaybackState `json:"AudioPlayer"`
+		System      struct {
+			APIAccessToken string `json:"apiAccessToken"`
+			APIEndpoint    string `json:"apiEndpoint"`
+			Application    struct {
+				ID string `json:"applicationId"`
+			} `json:"application"`
+			Device Device `json:"device"`
+			Person Person `json:"person"`
+			User   struct {
+				AccessToken string      `json:"accessToken"`
+				ID          string      `json:"userId"`
+				Permissions Permissions `json:"permissions"`
+			} `json:"user"`
+		} `json:"system"`
+	} `json:"context"`
+	Request struct {
+		RequestID string `json:"requestId"`
+		Timestamp string `json:"timestamp"`
+		Locale    string `json:"locale"`
+	} `json:"request"`
+}
+
+// A ReminderEventRequest represents the payload provided by Amazon when a
+// reminder created by the skill is created, updated, deleted, started or
+// changes status.
+type ReminderEventRequest struct {
 	Version string `json:"version"`
 	Context struct {
 		System struct {
-			APIEndpoint string `json:"apiEndpoint"`
-			Application struct {
+			APIAccessToken string `json:"apiAccessToken"`
+			APIEndpoint    string `json:"apiEndpoint"`
+			Application    struct {
 				ID string `json:"applicationId"`
 			} `json:"application"`
-			User struct {
-				AccessToken string `json:"accessToken"`
-				ID          string `json:"userId"`
-				Permissions struct {
-					ConsentToken string `json:"consentToken"`
-				} `json:"permissions"`
+			Device Device `json:"device"`
+			Person Person `json:"person"`
+			User   struct {
+				AccessToken string      `json:"accessToken"`
+				ID          string      `json:"userId"`
+				Permissions Permissions `json:"permissions"`
 			} `json:"user"`
 		} `json:"system"`
 	} `json:"context"`
 	Request struct {
+		Type      string `json:"type"`
 		RequestID string `json:"requestId"`
 		Timestamp string `json:"timestamp"`
 		Locale    string `json:"locale"`
+		Body      struct {
+			AlertToken  string   `json:"alertToken"`
+			AlertTokens []string `json:"alertTokens"`
+			Status      string   `json:"status"`
+		} `json:"body"`
 	} `json:"request"`
 }
 
@@ -248,17 +451,19 @@ type PlaybackControllerRequest struct {
 type SessionEndedRequest struct {
 	Version string `json:"version"`
 	Context struct {
-		System struct {
-			APIEndpoint string `json:"apiEndpoint"`
-			Application struct {
+		AudioPlayer PlaybackState `json:"AudioPlayer"`
+		System      struct {
+			APIAccessToken string `json:"apiAccessToken"`
+			APIEndpoint    string `json:"apiEndpoint"`
+			Application    struct {
 				ID string `json:"applicationId"`
 			} `json:"application"`
-			User struct {
-				AccessToken string `json:"accessToken"`
-				ID          string `json:"userId"`
-				Permissions struct {
-					ConsentToken string `json:"consentToken"`
-				} `json:"permissions"`
+			Device Device `json:"device"`
+			Person Person `json:"person"`
+			User   struct {
+				AccessToken string      `json:"accessToken"`
+				ID          string      `json:"userId"`
+				Permissions Permissions `json:"permissions"`
 			} `json:"user"`
 		} `json:"system"`
 	} `json:"context"`
@@ -270,11 +475,9 @@ type SessionEndedRequest struct {
 		ID         string                 `json:"sessionId"`
 		New        bool                   `json:"new"`
 		User       struct {
-			AccessToken string `json:"accessToken"`
-			ID          string `json:"userId"`
-			Permissions struct {
-				ConsentToken string `json:"consentToken"`
-			} `json:"permissions"`
+			AccessToken string      `json:"accessToken"`
+			ID          string      `json:"userId"`
+			Permissions Permissions `json:"permissions"`
 		} `json:"user"`
 	} `json:"session"`
 	Request struct {
@@ -294,17 +497,19 @@ type SessionEndedRequest struct {
 type SystemExceptionEncounteredRequest struct {
 	Version string `json:"version"`
 	Context struct {
-		System struct {
-			APIEndpoint string `json:"apiEndpoint"`
-			Application struct {
+		AudioPlayer PlaybackState `json:"AudioPlayer"`
+		System      struct {
+			APIAccessToken string `json:"apiAccessToken"`
+			APIEndpoint    string `json:"apiEndpoint"`
+			Application    struct {
 				ID string `json:"applicationId"`
 			} `json:"application"`
-			User struct {
-				AccessToken string `json:"accessToken"`
-				ID          string `json:"userId"`
-				Permissions struct {
-					ConsentToken string `json:"consentToken"`
-				} `json:"permissions"`
+			Device Device `json:"device"`
+			Person Person `json:"person"`
+			User   struct {
+				AccessToken string      `json:"accessToken"`
+				ID          string      `json:"userId"`
+				Permissions Permissions `json:"permissions"`
 			} `json:"user"`
 		} `json:"system"`
 	} `json:"context"`
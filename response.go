@@ -2,20 +2,49 @@ package alexa
 
 import (
 	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/benjic/alexa/i18n"
 )
 
 const (
-	version                   = "1.0"
-	plainTextOutputSpeechType = "PlainText"
-	ssmlOutputSpeechType      = "SSML"
-	simpleCardType            = "Simple"
-	standardCardType          = "Standard"
-	linkAccountCardType       = "LinkAccount"
+	version                          = "1.0"
+	plainTextOutputSpeechType        = "PlainText"
+	ssmlOutputSpeechType             = "SSML"
+	simpleCardType                   = "Simple"
+	standardCardType                 = "Standard"
+	linkAccountCardType              = "LinkAccount"
+	askForPermissionsConsentCardType = "AskForPermissionsConsent"
+	videoAppLaunchType               = "VideoApp.Launch"
+	connectionsSendRequestType       = "Connections.SendRequest"
+	buyConnectionName                = "Buy"
+	upsellConnectionName             = "Upsell"
+	cancelConnectionName             = "Cancel"
+	webVTTCaptionType                = "WEBVTT"
+
+	audioPlayerPlayType         = "AudioPlayer.Play"
+	audioPlayerStopType         = "AudioPlayer.Stop"
+	audioPlayerClearQueueType   = "AudioPlayer.ClearQueue"
+	replaceAllPlayBehavior      = "REPLACE_ALL"
+	enqueuePlayBehavior         = "ENQUEUE"
+	replaceEnqueuedPlayBehavior = "REPLACE_ENQUEUED"
+	clearEnqueuedClearBehavior  = "CLEAR_ENQUEUED"
+	clearAllClearBehavior       = "CLEAR_ALL"
 )
 
+// ErrNoCatalog is returned when a message is spoken by a Handler without a
+// Catalog.
+var ErrNoCatalog = errors.New("handler has no catalog")
+
+// ErrVideoUnsupported is returned when a video is launched on a device that
+// does not support the VideoApp interface.
+var ErrVideoUnsupported = errors.New("device does not support VideoApp")
+
 // A Response allows a handler to construct a valid response to return to
 // the Alexa service.
 type Response interface {
+	AskForPermissionsConsentCard(permissions ...Permission)
 	LinkAccountCard()
 	PlainText(text string)
 	SSML(ssml string)
@@ -26,14 +55,100 @@ type Response interface {
 	StandardCard(title, text, smallImageURL, largeImageURL string)
 
 	AudioPlayerStopperQueueClearer
+	Purchaser
+	Speaker
+	VideoLauncher
+}
+
+// A Speaker allows a handler to speak messages from the Catalog of the Handler
+// in the locale of the request.
+type Speaker interface {
+	// Speak sets the output speech to the message for the key. Messages
+	// starting with <speak> are spoken as SSML, with the args escaped, and
+	// any other as plain text.
+	Speak(key string, args i18n.Args) error
+	// RepromptSpeak sets the reprompt to the message for the key like Speak.
+	RepromptSpeak(key string, args i18n.Args) error
+}
+
+// A Purchaser allows a handler to hand the customer to Amazon to buy, be
+// offered or cancel an in-skill product. The outcome is delivered to the
+// ConnectionsResponseRequest function of a Handler along with the token, which
+// can be used to resume the skill where it left off. Amazon speaks to the
+// customer on behalf of the skill so the response ends the session.
+type Purchaser interface {
+	Buy(productID, token string)
+	Upsell(productID, message, token string)
+	CancelPurchase(productID, token string)
+}
+
+// A VideoLauncher allows a handler to play a video on a device with a screen.
+type VideoLauncher interface {
+	LaunchVideo(url, title, subtitle string) error
 }
 
 // An AudioPlayer allows a handler to enqueue or replace the audio playing on
 // a device.
 type AudioPlayer interface {
-	ReplaceAllAudio(token, url string, offsetInMilliseconds int)
-	EnqueueAudio(token, url, expectedPreviousToken string, offsetInMilliseconds int)
-	ReplacedEnqueuedAudio(token, url string, offsetInMilliseconds int)
+	// ReplaceAllAudio immediately plays the stream, replacing the current and
+	// any enqueued streams.
+	ReplaceAllAudio(p PlayRequest)
+	// EnqueueAudio adds the stream to the end of the queue. The request must
+	// provide the ExpectedPreviousToken.
+	EnqueueAudio(p PlayRequest)
+	// ReplaceEnqueuedAudio replaces any enqueued streams without affecting
+	// the current stream.
+	ReplaceEnqueuedAudio(p PlayRequest)
+}
+
+// A PlayRequest describes an audio stream to be played on a device.
+type PlayRequest struct {
+	// Token identifies the stream in subsequent AudioPlayer requests.
+	Token string
+	// URL locates the stream. It must be served over HTTPS.
+	URL string
+	// ExpectedPreviousToken is the token of the stream that must be playing
+	// when an enqueued stream starts. It is only sent when enqueueing.
+	ExpectedPreviousToken string
+	// OffsetInMilliseconds is the position playback begins from.
+	OffsetInMilliseconds int
+	// Metadata is optionally displayed by devices with a screen.
+	Metadata *AudioItemMetadata
+	// Caption is optionally displayed alongside the stream.
+	Caption *CaptionData
+}
+
+// AudioItemMetadata describes an audio stream to devices that can display
+// what is playing such as the Echo Show or the Alexa app.
+type AudioItemMetadata struct {
+	Title           string `json:"title,omitempty"`
+	Subtitle        string `json:"subtitle,omitempty"`
+	Art             *Image `json:"art,omitempty"`
+	BackgroundImage *Image `json:"backgroundImage,omitempty"`
+}
+
+// An Image is a picture provided in one or more sizes. The device chooses the
+// source best suited to its screen.
+type Image struct {
+	ContentDescription string        `json:"contentDescription,omitempty"`
+	Sources            []ImageSource `json:"sources"`
+}
+
+// An ImageSource is a single rendition of an Image. Size should be one of
+// X_SMALL, SMALL, MEDIUM, LARGE or X_LARGE and may be omitted when the
+// dimensions are given.
+type ImageSource struct {
+	URL          string `json:"url"`
+	Size         string `json:"size,omitempty"`
+	WidthPixels  int    `json:"widthPixels,omitempty"`
+	HeightPixels int    `json:"heightPixels,omitempty"`
+}
+
+// CaptionData holds the captions displayed alongside an audio stream. Type
+// defaults to WEBVTT when empty.
+type CaptionData struct {
+	Content string `json:"content"`
+	Type    string `json:"type"`
 }
 
 // An AudioStopper allows a handler to stop the playback of audio on a device.
@@ -71,14 +186,16 @@ type playDirective struct {
 }
 
 type playDirectiveAudioItem struct {
-	Stream playDirectiveAudioStream `json:"stream"`
+	Stream   playDirectiveAudioStream `json:"stream"`
+	Metadata *AudioItemMetadata       `json:"metadata,omitempty"`
 }
 
 type playDirectiveAudioStream struct {
-	URL                   string  `json:"url"`
-	Token                 string  `json:"token"`
-	ExpectedPreviousToken *string `json:"expectedPreviousToken,omitempty"`
-	OffsetInMilliseconds  int     `json:"offsetInMilliseconds"`
+	URL                   string       `json:"url"`
+	Token                 string       `json:"token"`
+	ExpectedPreviousToken *string      `json:"expectedPreviousToken,omitempty"`
+	OffsetInMilliseconds  int          `json:"offsetInMilliseconds"`
+	CaptionData           *CaptionData `json:"captionData,omitempty"`
 }
 
 type stopDirective struct {
@@ -90,29 +207,64 @@ type clearAudioQueueDirective struct {
 	ClearBehavior string `json:"clearBehavior"`
 }
 
+type videoAppLaunchDirective struct {
+	Type      string    `json:"type"`
+	VideoItem videoItem `json:"videoItem"`
+}
+
+type videoItem struct {
+	Source   string             `json:"source"`
+	Metadata *videoItemMetadata `json:"metadata,omitempty"`
+}
+
+type videoItemMetadata struct {
+	Title    string `json:"title,omitempty"`
+	Subtitle string `json:"subtitle,omitempty"`
+}
+
+type connectionsSendRequestDirective struct {
+	Type    string                        `json:"type"`
+	Name    string                        `json:"name"`
+	Payload connectionsSendRequestPayload `json:"payload"`
+	Token   string                        `json:"token"`
+}
+
+type connectionsSendRequestPayload struct {
+	InSkillProduct struct {
+		ProductID string `json:"productId"`
+	} `json:"InSkillProduct"`
+	UpsellMessage string `json:"upsellMessage,omitempty"`
+}
+
 type outputSpeech struct {
 	SSML *string `json:"ssml,omitempty"`
 	Text *string `json:"text,omitempty"`
 	Type string  `json:"type"`
 }
 
-type image struct {
+type cardImage struct {
 	LargeImageURL string `json:"largeImageUrl"`
 	SmallImageURL string `json:"smallImageUrl"`
 }
 
 type card struct {
-	Content *string `json:"content,omitempty"`
-	Image   *image  `json:"image,omitempty"`
-	Text    *string `json:"text,omitempty"`
-	Title   *string `json:"title,omitempty"`
-	Type    string  `json:"type"`
+	Content     *string      `json:"content,omitempty"`
+	Image       *cardImage   `json:"image,omitempty"`
+	Permissions []Permission `json:"permissions,omitempty"`
+	Text        *string      `json:"text,omitempty"`
+	Title       *string      `json:"title,omitempty"`
+	Type        string       `json:"type"`
 }
 
 // Response passes data back to Alexa.
 type responseBuilder struct {
 	Version  string    `json:"version"`
 	Response *response `json:"response"`
+
+	device      Device
+	requestType RequestType
+	locale      string
+	catalog     *i18n.Catalog
 }
 
 type response struct {
@@ -127,6 +279,8 @@ type responseDirectives struct {
 	playDirective            *playDirective
 	stopAudioDirective       *stopDirective
 	clearAudioQueueDirective *clearAudioQueueDirective
+	videoAppLaunchDirective  *videoAppLaunchDirective
+	connectionsSendRequest   *connectionsSendRequestDirective
 }
 
 func (d responseDirectives) MarshalJSON() ([]byte, error) {
@@ -144,6 +298,14 @@ func (d responseDirectives) MarshalJSON() ([]byte, error) {
 		ds = append(ds, d.clearAudioQueueDirective)
 	}
 
+	if d.videoAppLaunchDirective != nil {
+		ds = append(ds, d.videoAppLaunchDirective)
+	}
+
+	if d.connectionsSendRequest != nil {
+		ds = append(ds, d.connectionsSendRequest)
+	}
+
 	return json.Marshal(ds)
 }
 
@@ -176,6 +338,7 @@ func (b *responseBuilder) SimpleCard(title, content string) {
 
 	b.Response.Card.Content = &content
 	b.Response.Card.Image = nil
+	b.Response.Card.Permissions = nil
 	b.Response.Card.Text = nil
 	b.Response.Card.Title = &title
 	b.Response.Card.Type = simpleCardType
@@ -187,7 +350,8 @@ func (b *responseBuilder) StandardCard(title, text, smallImageURL, largeImageURL
 	}
 
 	b.Response.Card.Content = nil
-	b.Response.Card.Image = &image{smallImageURL, largeImageURL}
+	b.Response.Card.Image = &cardImage{LargeImageURL: largeImageURL, SmallImageURL: smallImageURL}
+	b.Response.Card.Permissions = nil
 	b.Response.Card.Text = &text
 	b.Response.Card.Title = &title
 	b.Response.Card.Type = standardCardType
@@ -200,11 +364,25 @@ func (b *responseBuilder) LinkAccountCard() {
 
 	b.Response.Card.Content = nil
 	b.Response.Card.Image = nil
+	b.Response.Card.Permissions = nil
 	b.Response.Card.Text = nil
 	b.Response.Card.Title = nil
 	b.Response.Card.Type = linkAccountCardType
 }
 
+func (b *responseBuilder) AskForPermissionsConsentCard(permissions ...Permission) {
+	if b.Response.Card == nil {
+		b.Response.Card = &card{}
+	}
+
+	b.Response.Card.Content = nil
+	b.Response.Card.Image = nil
+	b.Response.Card.Permissions = permissions
+	b.Response.Card.Text = nil
+	b.Response.Card.Title = nil
+	b.Response.Card.Type = askForPermissionsConsentCardType
+}
+
 func (b *responseBuilder) RepromptPlainText(text string) {
 	if b.Response.Reprompt == nil {
 		b.Response.Reprompt = &reprompt{&outputSpeech{}}
@@ -227,75 +405,182 @@ func (b *responseBuilder) ShouldEndSession(value bool) {
 	b.Response.ShouldEndSession = &value
 }
 
-func (b *responseBuilder) ReplaceAllAudio(token, url string, offsetInMilliseconds int) {
+func (b *responseBuilder) ReplaceAllAudio(p PlayRequest) {
+	b.play(replaceAllPlayBehavior, p)
+}
+
+func (b *responseBuilder) EnqueueAudio(p PlayRequest) {
+	b.play(enqueuePlayBehavior, p)
+}
+
+func (b *responseBuilder) ReplaceEnqueuedAudio(p PlayRequest) {
+	b.play(replaceEnqueuedPlayBehavior, p)
+}
+
+// play sets the play directive for the response with the given behavior.
+func (b *responseBuilder) play(behavior string, p PlayRequest) {
 	if b.Response.Directives == nil {
 		b.Response.Directives = &responseDirectives{}
 	}
 
+	stream := playDirectiveAudioStream{
+		OffsetInMilliseconds: p.OffsetInMilliseconds,
+		Token:                p.Token,
+		URL:                  p.URL,
+	}
+
+	if behavior == enqueuePlayBehavior {
+		expectedPreviousToken := p.ExpectedPreviousToken
+		stream.ExpectedPreviousToken = &expectedPreviousToken
+	}
+
+	if p.Caption != nil {
+		c := *p.Caption
+		if c.Type == "" {
+			c.Type = webVTTCaptionType
+		}
+		stream.CaptionData = &c
+	}
+
 	b.Response.Directives.playDirective = &playDirective{
-		Type:         "AudioPlayer.Play",
-		PlayBehavior: "REPLACE_ALL",
+		Type:         audioPlayerPlayType,
+		PlayBehavior: behavior,
 		AudioItem: playDirectiveAudioItem{
-			playDirectiveAudioStream{
-				OffsetInMilliseconds: offsetInMilliseconds,
-				Token:                token,
-				URL:                  url,
-			},
+			Stream:   stream,
+			Metadata: p.Metadata,
 		},
 	}
 }
 
-func (b *responseBuilder) EnqueueAudio(token, expectedPreviousToken, url string, offsetInMilliseconds int) {
+func (b *responseBuilder) StopAudio() {
 	if b.Response.Directives == nil {
 		b.Response.Directives = &responseDirectives{}
 	}
 
-	b.Response.Directives.playDirective = &playDirective{
-		Type:         "AudioPlayer.Play",
-		PlayBehavior: "ENQUEUE",
-		AudioItem: playDirectiveAudioItem{
-			playDirectiveAudioStream{
-				ExpectedPreviousToken: &expectedPreviousToken,
-				OffsetInMilliseconds:  offsetInMilliseconds,
-				Token:                 token,
-				URL:                   url,
-			},
-		},
+	b.Response.Directives.stopAudioDirective = &stopDirective{Type: audioPlayerStopType}
+}
+
+func (b *responseBuilder) ClearEnqueuedAudio() {
+	b.clearQueue(clearEnqueuedClearBehavior)
+}
+
+func (b *responseBuilder) ClearAllAudio() {
+	b.clearQueue(clearAllClearBehavior)
+}
+
+// clearQueue sets the clear queue directive for the response with the given
+// behavior.
+func (b *responseBuilder) clearQueue(behavior string) {
+	if b.Response.Directives == nil {
+		b.Response.Directives = &responseDirectives{}
+	}
+
+	b.Response.Directives.clearAudioQueueDirective = &clearAudioQueueDirective{
+		Type:          audioPlayerClearQueueType,
+		ClearBehavior: behavior,
 	}
 }
 
-func (b *responseBuilder) ReplacedEnqueuedAudio(token, url string, offsetInMilliseconds int) {
+// LaunchVideo plays the video at the given url with optional title and
+// subtitle metadata. Amazon rejects responses that launch a video on a device
+// without a screen so ErrVideoUnsupported is returned instead. A response
+// launching a video cannot keep the session open so any previous
+// ShouldEndSession value is removed.
+func (b *responseBuilder) LaunchVideo(url, title, subtitle string) error {
+	if !b.device.SupportsVideo() {
+		return ErrVideoUnsupported
+	}
+
 	if b.Response.Directives == nil {
 		b.Response.Directives = &responseDirectives{}
 	}
 
-	b.Response.Directives.playDirective = &playDirective{
-		Type:         "AudioPlayer.Play",
-		PlayBehavior: "REPLACE_ENQUEUED",
-		AudioItem: playDirectiveAudioItem{
-			playDirectiveAudioStream{
-				OffsetInMilliseconds: offsetInMilliseconds,
-				Token:                token,
-				URL:                  url,
-			},
-		},
+	item := videoItem{Source: url}
+	if title != "" || subtitle != "" {
+		item.Metadata = &videoItemMetadata{Title: title, Subtitle: subtitle}
+	}
+
+	b.Response.ShouldEndSession = nil
+	b.Response.Directives.videoAppLaunchDirective = &videoAppLaunchDirective{
+		Type:      videoAppLaunchType,
+		VideoItem: item,
 	}
+
+	return nil
 }
 
-func (b responseBuilder) StopAudio() {
-	b.Response.Directives.stopAudioDirective = &stopDirective{Type: "AudioPlayer.Stop"}
+// Buy starts the purchase flow for the product.
+func (b *responseBuilder) Buy(productID, token string) {
+	b.sendRequest(buyConnectionName, productID, "", token)
 }
 
-func (b responseBuilder) ClearEnqueuedAudio() {
-	b.Response.Directives.clearAudioQueueDirective = &clearAudioQueueDirective{
-		Type:          "AudioPlayer.ClearQueue",
-		ClearBehavior: "CLEAR_ENQUEUED",
+// Upsell offers the product to the customer after the message, which should
+// describe the product and end by asking whether they would like to learn
+// more.
+func (b *responseBuilder) Upsell(productID, message, token string) {
+	b.sendRequest(upsellConnectionName, productID, message, token)
+}
+
+// CancelPurchase starts the cancellation or refund flow for the product.
+func (b *responseBuilder) CancelPurchase(productID, token string) {
+	b.sendRequest(cancelConnectionName, productID, "", token)
+}
+
+// sendRequest sets the Connections.SendRequest directive for the response
+// with the given name.
+func (b *responseBuilder) sendRequest(name, productID, message, token string) {
+	if b.Response.Directives == nil {
+		b.Response.Directives = &responseDirectives{}
+	}
+
+	payload := connectionsSendRequestPayload{UpsellMessage: message}
+	payload.InSkillProduct.ProductID = productID
+
+	b.ShouldEndSession(true)
+	b.Response.Directives.connectionsSendRequest = &connectionsSendRequestDirective{
+		Type:    connectionsSendRequestType,
+		Name:    name,
+		Payload: payload,
+		Token:   token,
 	}
 }
 
-func (b responseBuilder) ClearAllAudio() {
-	b.Response.Directives.clearAudioQueueDirective = &clearAudioQueueDirective{
-		Type:          "AudioPlayer.ClearQueue",
-		ClearBehavior: "CLEAR_ALL",
+// Speak sets the output speech to the message for the key in the locale of the
+// request.
+func (b *responseBuilder) Speak(key string, args i18n.Args) error {
+	text, err := b.lookup(key, args)
+	if err != nil {
+		return err
+	}
+
+	if strings.HasPrefix(text, "<speak>") {
+		b.SSML(text)
+	} else {
+		b.PlainText(text)
+	}
+	return nil
+}
+
+// RepromptSpeak sets the reprompt to the message for the key in the locale of
+// the request.
+func (b *responseBuilder) RepromptSpeak(key string, args i18n.Args) error {
+	text, err := b.lookup(key, args)
+	if err != nil {
+		return err
+	}
+
+	if strings.HasPrefix(text, "<speak>") {
+		b.RepromptSSML(text)
+	} else {
+		b.RepromptPlainText(text)
+	}
+	return nil
+}
+
+// lookup returns the message for the key in the locale of the request.
+func (b *responseBuilder) lookup(key string, args i18n.Args) (string, error) {
+	if b.catalog == nil {
+		return "", ErrNoCatalog
 	}
+	return b.catalog.Lookup(b.locale, key, args)
 }
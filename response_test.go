@@ -0,0 +1,349 @@
+package alexa
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/benjic/alexa/i18n"
+)
+
+// assertJSON compares the encoded value v against the expected JSON document.
+func assertJSON(t *testing.T, v interface{}, expected string) {
+	t.Helper()
+
+	bs, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal: %s", err)
+	}
+
+	var got, want interface{}
+	if err := json.Unmarshal(bs, &got); err != nil {
+		t.Fatalf("failed to unmarshal got: %s", err)
+	}
+	if err := json.Unmarshal([]byte(expected), &want); err != nil {
+		t.Fatalf("failed to unmarshal want: %s", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wanted %s; got %s", expected, bs)
+	}
+}
+
+func TestLaunchVideo(t *testing.T) {
+	b := &responseBuilder{Version: version, Response: &response{}}
+	b.device.SupportedInterfaces.VideoApp = &struct{}{}
+	b.ShouldEndSession(true)
+
+	if err := b.LaunchVideo("https://example.com/video.mp4", "Title", "Subtitle"); err != nil {
+		t.Fatalf("Did not want err; got %s", err)
+	}
+
+	assertJSON(t, b, `{
+		"version": "1.0",
+		"response": {
+			"directives": [
+				{
+					"type": "VideoApp.Launch",
+					"videoItem": {
+						"source": "https://example.com/video.mp4",
+						"metadata": {
+							"title": "Title",
+							"subtitle": "Subtitle"
+						}
+					}
+				}
+			]
+		}
+	}`)
+}
+
+func TestLaunchVideoUnsupported(t *testing.T) {
+	b := &responseBuilder{Version: version, Response: &response{}}
+
+	if err := b.LaunchVideo("https://example.com/video.mp4", "", ""); err != ErrVideoUnsupported {
+		t.Errorf("Wanted %s; got %v", ErrVideoUnsupported, err)
+	}
+	if b.Response.Directives != nil {
+		t.Errorf("Did not want directives; got %+v", b.Response.Directives)
+	}
+}
+
+func TestReplaceAllAudioMetadata(t *testing.T) {
+	b := &responseBuilder{Version: version, Response: &response{}}
+	b.ReplaceAllAudio(PlayRequest{
+		Token:                "token",
+		URL:                  "https://example.com/audio.mp3",
+		OffsetInMilliseconds: 100,
+		Metadata: &AudioItemMetadata{
+			Title:    "Title",
+			Subtitle: "Subtitle",
+			Art: &Image{
+				ContentDescription: "Art",
+				Sources: []ImageSource{
+					{URL: "https://example.com/small.png", Size: "SMALL"},
+					{URL: "https://example.com/large.png", WidthPixels: 1200, HeightPixels: 800},
+				},
+			},
+		},
+		Caption: &CaptionData{Content: "WEBVTT\n\n00:00.000 --> 00:01.000\nHello"},
+	})
+
+	assertJSON(t, b, `{
+		"version": "1.0",
+		"response": {
+			"directives": [
+				{
+					"type": "AudioPlayer.Play",
+					"playBehavior": "REPLACE_ALL",
+					"audioItem": {
+						"stream": {
+							"url": "https://example.com/audio.mp3",
+							"token": "token",
+							"offsetInMilliseconds": 100,
+							"captionData": {
+								"content": "WEBVTT\n\n00:00.000 --> 00:01.000\nHello",
+								"type": "WEBVTT"
+							}
+						},
+						"metadata": {
+							"title": "Title",
+							"subtitle": "Subtitle",
+							"art": {
+								"contentDescription": "Art",
+								"sources": [
+									{"url": "https://example.com/small.png", "size": "SMALL"},
+									{"url": "https://example.com/large.png", "widthPixels": 1200, "heightPixels": 800}
+								]
+							}
+						}
+					}
+				}
+			]
+		}
+	}`)
+}
+
+func TestAudioPlayerDirectives(t *testing.T) {
+	p := PlayRequest{
+		Token:                 "track-2",
+		URL:                   "https://example.com/track-2.mp3",
+		ExpectedPreviousToken: "track-1",
+		OffsetInMilliseconds:  0,
+	}
+
+	cases := []struct {
+		name     string
+		build    func(*responseBuilder)
+		expected string
+	}{
+		{
+			"ReplaceAllAudio",
+			func(b *responseBuilder) { b.ReplaceAllAudio(p) },
+			`{
+				"type": "AudioPlayer.Play",
+				"playBehavior": "REPLACE_ALL",
+				"audioItem": {
+					"stream": {
+						"token": "track-2",
+						"url": "https://example.com/track-2.mp3",
+						"offsetInMilliseconds": 0
+					}
+				}
+			}`,
+		},
+		{
+			"EnqueueAudio",
+			func(b *responseBuilder) { b.EnqueueAudio(p) },
+			`{
+				"type": "AudioPlayer.Play",
+				"playBehavior": "ENQUEUE",
+				"audioItem": {
+					"stream": {
+						"token": "track-2",
+						"url": "https://example.com/track-2.mp3",
+						"expectedPreviousToken": "track-1",
+						"offsetInMilliseconds": 0
+					}
+				}
+			}`,
+		},
+		{
+			"ReplaceEnqueuedAudio",
+			func(b *responseBuilder) { b.ReplaceEnqueuedAudio(p) },
+			`{
+				"type": "AudioPlayer.Play",
+				"playBehavior": "REPLACE_ENQUEUED",
+				"audioItem": {
+					"stream": {
+						"token": "track-2",
+						"url": "https://example.com/track-2.mp3",
+						"offsetInMilliseconds": 0
+					}
+				}
+			}`,
+		},
+		{
+			"StopAudio",
+			func(b *responseBuilder) { b.StopAudio() },
+			`{"type": "AudioPlayer.Stop"}`,
+		},
+		{
+			"ClearEnqueuedAudio",
+			func(b *responseBuilder) { b.ClearEnqueuedAudio() },
+			`{"type": "AudioPlayer.ClearQueue", "clearBehavior": "CLEAR_ENQUEUED"}`,
+		},
+		{
+			"ClearAllAudio",
+			func(b *responseBuilder) { b.ClearAllAudio() },
+			`{"type": "AudioPlayer.ClearQueue", "clearBehavior": "CLEAR_ALL"}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			b := &responseBuilder{Version: version, Response: &response{}}
+			c.build(b)
+			assertJSON(t, b.Response.Directives, "["+c.expected+"]")
+		})
+	}
+}
+
+func TestPlayDirectiveRoundTrip(t *testing.T) {
+	// The directive as documented by Amazon.
+	documented := `{
+		"type": "AudioPlayer.Play",
+		"playBehavior": "ENQUEUE",
+		"audioItem": {
+			"stream": {
+				"url": "https://cdn.example.com/url-of-the-stream-to-play",
+				"token": "opaque token representing this stream",
+				"expectedPreviousToken": "opaque token representing the previous stream",
+				"offsetInMilliseconds": 0,
+				"captionData": {
+					"content": "WEBVTT\n\n00:00.000 --> 00:02.000\nCaption",
+					"type": "WEBVTT"
+				}
+			},
+			"metadata": {
+				"title": "title of the track to display",
+				"subtitle": "subtitle of the track to display",
+				"art": {
+					"sources": [
+						{"url": "https://cdn.example.com/url-of-the-album-art-image.png"}
+					]
+				},
+				"backgroundImage": {
+					"sources": [
+						{"url": "https://cdn.example.com/url-of-the-background-image.png"}
+					]
+				}
+			}
+		}
+	}`
+
+	var d playDirective
+	if err := json.Unmarshal([]byte(documented), &d); err != nil {
+		t.Fatalf("Failed to unmarshal: %s", err)
+	}
+	assertJSON(t, d, documented)
+
+	b := &responseBuilder{Version: version, Response: &response{}}
+	b.EnqueueAudio(PlayRequest{
+		Token:                 d.AudioItem.Stream.Token,
+		URL:                   d.AudioItem.Stream.URL,
+		ExpectedPreviousToken: *d.AudioItem.Stream.ExpectedPreviousToken,
+		OffsetInMilliseconds:  d.AudioItem.Stream.OffsetInMilliseconds,
+		Metadata:              d.AudioItem.Metadata,
+		Caption:               &CaptionData{Content: d.AudioItem.Stream.CaptionData.Content},
+	})
+	assertJSON(t, b.Response.Directives.playDirective, documented)
+}
+
+func TestAskForPermissionsConsentCard(t *testing.T) {
+	b := &responseBuilder{Version: version, Response: &response{}}
+	b.SimpleCard("Title", "Content")
+	b.AskForPermissionsConsentCard(FullAddressPermission)
+
+	assertJSON(t, b.Response.Card, `{
+		"type": "AskForPermissionsConsent",
+		"permissions": ["read::alexa:device:all:address"]
+	}`)
+}
+
+func TestPurchaserDirectives(t *testing.T) {
+	b := &responseBuilder{Version: version, Response: &response{}}
+	b.Upsell("amzn1.adg.product.TEST", "Premium has twice the facts. Want to learn more?", "facts")
+
+	assertJSON(t, b, `{
+		"version": "1.0",
+		"response": {
+			"directives": [
+				{
+					"type": "Connections.SendRequest",
+					"name": "Upsell",
+					"payload": {
+						"InSkillProduct": {
+							"productId": "amzn1.adg.product.TEST"
+						},
+						"upsellMessage": "Premium has twice the facts. Want to learn more?"
+					},
+					"token": "facts"
+				}
+			],
+			"shouldEndSession": true
+		}
+	}`)
+
+	b.CancelPurchase("amzn1.adg.product.TEST", "facts")
+	assertJSON(t, b.Response.Directives.connectionsSendRequest, `{
+		"type": "Connections.SendRequest",
+		"name": "Cancel",
+		"payload": {
+			"InSkillProduct": {
+				"productId": "amzn1.adg.product.TEST"
+			}
+		},
+		"token": "facts"
+	}`)
+}
+
+func TestSpeak(t *testing.T) {
+	c := i18n.NewCatalog("en-US")
+	if err := c.AddJSON("en", []byte(`{"hello": "Hello {name}!", "whisper": "<speak><amazon:effect name=\"whispered\">psst, {item}</amazon:effect></speak>"}`)); err != nil {
+		t.Fatalf("failed to add messages: %s", err)
+	}
+	if err := c.AddJSON("de", []byte(`{"hello": "Hallo {name}!"}`)); err != nil {
+		t.Fatalf("failed to add messages: %s", err)
+	}
+
+	b := &responseBuilder{Version: version, Response: &response{}, locale: "de-DE", catalog: c}
+	if err := b.Speak("hello", i18n.Args{"name": "Sam"}); err != nil {
+		t.Fatalf("Did not want err; got %s", err)
+	}
+	if err := b.RepromptSpeak("whisper", i18n.Args{"item": "fish & chips <large>"}); err != nil {
+		t.Fatalf("Did not want err; got %s", err)
+	}
+
+	assertJSON(t, b.Response, `{
+		"outputSpeech": {
+			"type": "PlainText",
+			"text": "Hallo Sam!"
+		},
+		"reprompt": {
+			"outputSpeech": {
+				"type": "SSML",
+				"ssml": "<speak><amazon:effect name=\"whispered\">psst, fish &amp; chips &lt;large&gt;</amazon:effect></speak>"
+			}
+		}
+	}`)
+
+	if err := b.Speak("missing", nil); err == nil {
+		t.Errorf("Wanted err for missing message")
+	}
+
+	b = &responseBuilder{Version: version, Response: &response{}, locale: "en-US"}
+	if err := b.Speak("hello", nil); err != ErrNoCatalog {
+		t.Errorf("Wanted %s; got %v", ErrNoCatalog, err)
+	}
+}
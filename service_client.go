@@ -0,0 +1,202 @@
+package alexa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 2
+	defaultBackoff    = 200 * time.Millisecond
+)
+
+// A ServiceClient makes authenticated requests to the Alexa service APIs. The
+// APIEndpoint and APIAccessToken are provided in the context of every request
+// made to a skill.
+type ServiceClient struct {
+	APIEndpoint    string
+	APIAccessToken string
+	HTTPClient     *http.Client
+
+	// MaxRetries is the number of times a request is retried after the API
+	// responds with a rate limit error, or with a server error to an
+	// idempotent request.
+	MaxRetries int
+	// Backoff is the delay before the first retry. The delay doubles with
+	// every following retry unless the API provides a Retry-After header.
+	Backoff time.Duration
+}
+
+// NewServiceClient returns a ServiceClient for the apiEndpoint and
+// apiAccessToken provided in the context of a request.
+func NewServiceClient(apiEndpoint, apiAccessToken string) *ServiceClient {
+	return &ServiceClient{
+		APIEndpoint:    apiEndpoint,
+		APIAccessToken: apiAccessToken,
+		HTTPClient:     http.DefaultClient,
+		MaxRetries:     defaultMaxRetries,
+		Backoff:        defaultBackoff,
+	}
+}
+
+// A ServiceError is returned when an Alexa service API responds with an
+// unsuccessful status.
+type ServiceError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	// RetryAfter is the delay requested by the API before another request is
+	// made. It is zero when the API did not provide one.
+	RetryAfter time.Duration
+}
+
+func (e *ServiceError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("unexpected response %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("unexpected response %d", e.StatusCode)
+}
+
+// RateLimited reports whether the request was rejected for exceeding the rate
+// limit of the API.
+func (e *ServiceError) RateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+// retryable reports whether a request made with method may succeed if
+// retried. Rate limited requests were never processed so they are always
+// retried. Server errors are only retried for idempotent methods since the
+// request may already have taken effect.
+func (e *ServiceError) retryable(method string) bool {
+	if e.RateLimited() {
+		return true
+	}
+	if e.StatusCode < http.StatusInternalServerError {
+		return false
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// Do sends a request to the API at path. A non nil in is encoded as the JSON
+// body of the request and a non nil out is decoded from the JSON body of a
+// successful response. Rate limited requests, and server errors for idempotent
+// methods, are retried up to MaxRetries times before a *ServiceError is
+// returned.
+func (c *ServiceClient) Do(ctx context.Context, method, path string, in, out interface{}) error {
+	return c.do(ctx, method, path, nil, in, out)
+}
+
+// do sends a request like Do with additional headers.
+func (c *ServiceClient) do(ctx context.Context, method, path string, header http.Header, in, out interface{}) error {
+	var bs []byte
+	if in != nil {
+		var err error
+		if bs, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+
+	backoff := c.Backoff
+	for attempt := 0; ; attempt++ {
+		err := c.send(ctx, method, path, header, bs, out)
+
+		serr, ok := err.(*ServiceError)
+		if !ok || !serr.retryable(method) || attempt >= c.MaxRetries {
+			return err
+		}
+
+		wait := backoff
+		if serr.RetryAfter > 0 {
+			wait = serr.RetryAfter
+		}
+		backoff *= 2
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// send makes a single attempt of a request.
+func (c *ServiceClient) send(ctx context.Context, method, path string, header http.Header, bs []byte, out interface{}) error {
+	var body io.Reader
+	if bs != nil {
+		body = bytes.NewReader(bs)
+	}
+
+	r, err := http.NewRequest(method, c.APIEndpoint+path, body)
+	if err != nil {
+		return err
+	}
+	r = r.WithContext(ctx)
+
+	for k, vs := range header {
+		r.Header[k] = vs
+	}
+	r.Header.Set("Authorization", "Bearer "+c.APIAccessToken)
+	r.Header.Set("Accept", "application/json")
+	if bs != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return newServiceError(resp)
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// newServiceError reads the error described by an unsuccessful response.
+func newServiceError(resp *http.Response) *ServiceError {
+	e := &ServiceError{StatusCode: resp.StatusCode}
+
+	var payload struct {
+		Code    string `json:"code"`
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err == nil {
+		e.Code = payload.Code
+		if e.Code == "" {
+			e.Code = payload.Type
+		}
+		e.Message = payload.Message
+	}
+
+	retryAfter := resp.Header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds > 0 {
+		e.RetryAfter = time.Duration(seconds) * time.Second
+	} else if t, err := http.ParseTime(retryAfter); err == nil && time.Until(t) > 0 {
+		e.RetryAfter = time.Until(t)
+	}
+
+	return e
+}
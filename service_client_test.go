@@ -0,0 +1,146 @@
+package alexa_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/benjic/alexa"
+)
+
+func TestServiceClientRetries(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		switch attempts {
+		case 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(`{"value": "ok"}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := alexa.NewServiceClient(srv.URL, "token")
+	c.Backoff = time.Millisecond
+
+	var out struct {
+		Value string `json:"value"`
+	}
+	if err := c.Do(context.Background(), http.MethodGet, "/v1/test", nil, &out); err != nil {
+		t.Fatalf("Did not want err; got %s", err)
+	}
+
+	if attempts != 3 || out.Value != "ok" {
+		t.Errorf("Wanted 3 attempts and ok; got %d and %q", attempts, out.Value)
+	}
+}
+
+func TestServiceClientErrors(t *testing.T) {
+	cases := []struct {
+		status      int
+		header      string
+		body        string
+		attempts    int
+		code        string
+		message     string
+		rateLimited bool
+		retryAfter  time.Duration
+	}{
+		{http.StatusForbidden, "", `{"code": "ACCESS_DENIED", "message": "Access denied"}`, 1, "ACCESS_DENIED", "Access denied", false, 0},
+		{http.StatusBadRequest, "", `{"type": "INVALID_REQUEST", "message": "Invalid"}`, 1, "INVALID_REQUEST", "Invalid", false, 0},
+		{http.StatusTooManyRequests, "1", ``, 3, "", "", true, time.Second},
+		{http.StatusInternalServerError, "", `not json`, 3, "", "", false, 0},
+	}
+
+	for _, c := range cases {
+		attempts := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if c.header != "" {
+				w.Header().Set("Retry-After", c.header)
+			}
+			w.WriteHeader(c.status)
+			w.Write([]byte(c.body))
+		}))
+
+		client := alexa.NewServiceClient(srv.URL, "token")
+		client.Backoff = time.Millisecond
+
+		// Cancelling the context during a Retry-After wait is tested separately.
+		if c.retryAfter > 0 {
+			client.MaxRetries = 0
+			c.attempts = 1
+		}
+
+		err := client.Do(context.Background(), http.MethodGet, "/", nil, nil)
+		srv.Close()
+
+		serr, ok := err.(*alexa.ServiceError)
+		if !ok {
+			t.Errorf("Wanted *ServiceError; got %T %v", err, err)
+			continue
+		}
+		if serr.StatusCode != c.status || serr.Code != c.code || serr.Message != c.message {
+			t.Errorf("Unexpected error %+v for status %d", serr, c.status)
+		}
+		if serr.RateLimited() != c.rateLimited || serr.RetryAfter != c.retryAfter {
+			t.Errorf("Wanted rate limited %t after %s; got %t after %s", c.rateLimited, c.retryAfter, serr.RateLimited(), serr.RetryAfter)
+		}
+		if attempts != c.attempts {
+			t.Errorf("Wanted %d attempts; got %d for status %d", c.attempts, attempts, c.status)
+		}
+	}
+}
+
+func TestServiceClientRetriesByMethod(t *testing.T) {
+	cases := []struct {
+		method   string
+		status   int
+		attempts int
+	}{
+		{http.MethodGet, http.StatusInternalServerError, 3},
+		{http.MethodPut, http.StatusBadGateway, 3},
+		{http.MethodDelete, http.StatusServiceUnavailable, 3},
+		{http.MethodPost, http.StatusInternalServerError, 1},
+		{http.MethodPost, http.StatusTooManyRequests, 3},
+	}
+
+	for _, c := range cases {
+		attempts := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(c.status)
+		}))
+
+		client := alexa.NewServiceClient(srv.URL, "token")
+		client.Backoff = time.Millisecond
+
+		client.Do(context.Background(), c.method, "/", nil, nil)
+		srv.Close()
+
+		if attempts != c.attempts {
+			t.Errorf("Wanted %d attempts; got %d for %s with status %d", c.attempts, attempts, c.method, c.status)
+		}
+	}
+}
+
+func TestServiceClientCancelledDuringBackoff(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := alexa.NewServiceClient(srv.URL, "token").Do(ctx, http.MethodGet, "/", nil, nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Wanted %s; got %v", context.DeadlineExceeded, err)
+	}
+}
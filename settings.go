@@ -0,0 +1,93 @@
+package alexa
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DistanceUnits are the units a customer has chosen to measure distance in.
+type DistanceUnits string
+
+// The distance units a customer is able to choose.
+const (
+	MetricDistanceUnits   DistanceUnits = "METRIC"
+	ImperialDistanceUnits DistanceUnits = "IMPERIAL"
+)
+
+// A TemperatureUnit is the unit a customer has chosen to measure temperature
+// in.
+type TemperatureUnit string
+
+// The temperature units a customer is able to choose.
+const (
+	CelsiusTemperatureUnit    TemperatureUnit = "CELSIUS"
+	FahrenheitTemperatureUnit TemperatureUnit = "FAHRENHEIT"
+)
+
+// A SettingsClient reads the settings of a device with the Alexa Settings API.
+// No permission is required to read settings.
+//
+// https://developer.amazon.com/docs/smapi/alexa-settings-api-reference.html
+type SettingsClient struct {
+	*ServiceClient
+}
+
+// NewSettingsClient returns a SettingsClient for the apiEndpoint and
+// apiAccessToken provided in the context of a request.
+func NewSettingsClient(apiEndpoint, apiAccessToken string) *SettingsClient {
+	return &SettingsClient{NewServiceClient(apiEndpoint, apiAccessToken)}
+}
+
+// TimeZone returns the time zone of the device as loaded by time.LoadLocation.
+func (c *SettingsClient) TimeZone(ctx context.Context, deviceID string) (*time.Location, error) {
+	var name string
+	if err := c.get(ctx, deviceID, "System.timeZone", &name); err != nil {
+		return nil, err
+	}
+	return time.LoadLocation(name)
+}
+
+// DistanceUnits returns the units the device measures distance in.
+func (c *SettingsClient) DistanceUnits(ctx context.Context, deviceID string) (DistanceUnits, error) {
+	var units DistanceUnits
+	if err := c.get(ctx, deviceID, "System.distanceUnits", &units); err != nil {
+		return "", err
+	}
+	return units, nil
+}
+
+// TemperatureUnit returns the unit the device measures temperature in.
+func (c *SettingsClient) TemperatureUnit(ctx context.Context, deviceID string) (TemperatureUnit, error) {
+	var unit TemperatureUnit
+	if err := c.get(ctx, deviceID, "System.temperatureUnit", &unit); err != nil {
+		return "", err
+	}
+	return unit, nil
+}
+
+// LocalTime returns the request timestamp in the time zone of the device.
+func (c *SettingsClient) LocalTime(ctx context.Context, deviceID, timestamp string) (time.Time, error) {
+	loc, err := c.TimeZone(ctx, deviceID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return LocalTime(timestamp, loc)
+}
+
+// get reads a single setting of the device into out.
+func (c *SettingsClient) get(ctx context.Context, deviceID, setting string, out interface{}) error {
+	path := "/v2/devices/" + url.PathEscape(deviceID) + "/settings/" + setting
+	return c.Do(ctx, http.MethodGet, path, nil, out)
+}
+
+// LocalTime parses the timestamp of a request and returns it in the given
+// location.
+func LocalTime(timestamp string, loc *time.Location) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.In(loc), nil
+}
@@ -0,0 +1,44 @@
+package alexa_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/benjic/alexa"
+)
+
+func TestSettingsClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/devices/device-id/settings/System.timeZone":
+			w.Write([]byte(`"America/Los_Angeles"`))
+		case "/v2/devices/device-id/settings/System.distanceUnits":
+			w.Write([]byte(`"METRIC"`))
+		case "/v2/devices/device-id/settings/System.temperatureUnit":
+			w.Write([]byte(`"FAHRENHEIT"`))
+		default:
+			t.Errorf("Unexpected request for %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := alexa.NewSettingsClient(srv.URL, "token")
+	ctx := context.Background()
+
+	if units, err := c.DistanceUnits(ctx, "device-id"); err != nil || units != alexa.MetricDistanceUnits {
+		t.Errorf("Wanted %s; got %s, %v", alexa.MetricDistanceUnits, units, err)
+	}
+	if unit, err := c.TemperatureUnit(ctx, "device-id"); err != nil || unit != alexa.FahrenheitTemperatureUnit {
+		t.Errorf("Wanted %s; got %s, %v", alexa.FahrenheitTemperatureUnit, unit, err)
+	}
+
+	local, err := c.LocalTime(ctx, "device-id", "2017-12-23T20:00:00Z")
+	if err != nil {
+		t.Skipf("Time zone database unavailable: %s", err)
+	}
+	if local.Hour() != 12 || local.Location().String() != "America/Los_Angeles" {
+		t.Errorf("Wanted 12:00 in America/Los_Angeles; got %s", local)
+	}
+}
@@ -0,0 +1,57 @@
+package alexa
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// SkillMessagingScope is the Login with Amazon scope of tokens used to
+	// send skill messages.
+	SkillMessagingScope = "alexa:skill_messaging"
+
+	skillMessagesPath = "/v1/skillmessages/users/"
+)
+
+// A SkillMessagingClient sends messages to a skill on behalf of a customer
+// with the Skill Messaging API. Messages are received by the
+// MessageReceivedRequest function of a Handler. Access tokens are obtained
+// from Tokens for every message sent.
+//
+// https://developer.amazon.com/docs/smapi/skill-messaging-api-reference.html
+type SkillMessagingClient struct {
+	*ServiceClient
+	Tokens TokenSource
+}
+
+// NewSkillMessagingClient returns a SkillMessagingClient sending messages
+// through the regional apiEndpoint, such as NorthAmericaAPIEndpoint.
+func NewSkillMessagingClient(apiEndpoint string, tokens TokenSource) *SkillMessagingClient {
+	return &SkillMessagingClient{
+		ServiceClient: NewServiceClient(apiEndpoint, ""),
+		Tokens:        tokens,
+	}
+}
+
+type skillMessage struct {
+	Data                map[string]interface{} `json:"data"`
+	ExpiresAfterSeconds int                    `json:"expiresAfterSeconds,omitempty"`
+}
+
+// Send delivers the data to the skill for the user. The message is discarded
+// if it is not delivered before expiresAfter. A zero expiresAfter uses the
+// default expiry of the API.
+func (c *SkillMessagingClient) Send(ctx context.Context, userID string, data map[string]interface{}, expiresAfter time.Duration) error {
+	sc, err := authorize(ctx, c.ServiceClient, c.Tokens)
+	if err != nil {
+		return err
+	}
+
+	m := skillMessage{
+		Data:                data,
+		ExpiresAfterSeconds: int(expiresAfter / time.Second),
+	}
+	return sc.Do(ctx, http.MethodPost, skillMessagesPath+url.PathEscape(userID), m, nil)
+}
@@ -0,0 +1,45 @@
+package alexa_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/benjic/alexa"
+)
+
+// staticTokens is a TokenSource always providing the same token.
+type staticTokens string
+
+func (s staticTokens) Token(context.Context) (string, error) { return string(s), nil }
+
+func TestSkillMessagingClientSend(t *testing.T) {
+	var got struct {
+		Data                map[string]interface{} `json:"data"`
+		ExpiresAfterSeconds int                    `json:"expiresAfterSeconds"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/skillmessages/users/amzn1.ask.account.TEST" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer token" {
+			t.Errorf("Wanted bearer token; got %q", auth)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	c := alexa.NewSkillMessagingClient(srv.URL, staticTokens("token"))
+	err := c.Send(context.Background(), "amzn1.ask.account.TEST", map[string]interface{}{"score": 42}, time.Hour)
+	if err != nil {
+		t.Fatalf("Did not want err; got %s", err)
+	}
+
+	if got.ExpiresAfterSeconds != 3600 || got.Data["score"] != float64(42) {
+		t.Errorf("Unexpected message %+v", got)
+	}
+}
@@ -0,0 +1,244 @@
+package alexa
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/benjic/alexa/amazontime"
+)
+
+// The built-in slot types a Slot is able to convert.
+const (
+	NumberSlotType          = "AMAZON.NUMBER"
+	DateSlotType            = "AMAZON.DATE"
+	TimeSlotType            = "AMAZON.TIME"
+	DurationSlotType        = "AMAZON.DURATION"
+	FourDigitNumberSlotType = "AMAZON.FOUR_DIGIT_NUMBER"
+)
+
+// ErrSlotNotFilled is returned when converting a slot the customer did not
+// provide a value for.
+var ErrSlotNotFilled = errors.New("slot not filled")
+
+// ErrSlotMultipleValues is returned when converting a slot accepting multiple
+// values. Each of its Values must be converted instead.
+var ErrSlotMultipleValues = errors.New("slot has multiple values; convert each of its Values")
+
+// A SlotError is returned when the value of a slot cannot be converted to the
+// requested slot type.
+type SlotError struct {
+	Name  string
+	Value string
+	Type  string
+	Err   error
+}
+
+func (e *SlotError) Error() string {
+	return fmt.Sprintf("slot %s: cannot convert %q to %s: %s", e.Name, e.Value, e.Type, e.Err)
+}
+
+// A Slot is a value captured from the utterance of a customer for an intent.
+type Slot struct {
+	Name               string      `json:"name"`
+	Value              string      `json:"value"`
+	ConfirmationStatus string      `json:"confirmationStatus"`
+	Source             string      `json:"source"`
+	Resolutions        Resolutions `json:"resolutions"`
+	SlotValue          *SlotValue  `json:"slotValue"`
+}
+
+// The kinds of SlotValue.
+const (
+	SimpleSlotValueType = "Simple"
+	ListSlotValueType   = "List"
+)
+
+// A SlotValue is the structured value of a slot. A Simple value holds a single
+// value and its resolutions while a List value holds the Simple values of a
+// slot accepting multiple values.
+type SlotValue struct {
+	Type        string      `json:"type"`
+	Value       string      `json:"value"`
+	Resolutions Resolutions `json:"resolutions"`
+	Values      []SlotValue `json:"values"`
+}
+
+// Slot returns the named slot of the intent. A slot not defined by the intent
+// is returned unfilled.
+func (r *IntentRequest) Slot(name string) Slot {
+	if s, ok := r.Request.Intent.Slots[name]; ok {
+		return s
+	}
+	return Slot{Name: name}
+}
+
+// Filled reports whether the customer provided a value for the slot.
+func (s Slot) Filled() bool {
+	return s.Value != "" || len(s.Values()) > 0
+}
+
+// Values returns every value the customer provided for the slot as a Slot of
+// the same name, so each can be converted or resolved on its own. A slot
+// accepting multiple values has one per value in the List while any other
+// filled slot has itself as its only value.
+func (s Slot) Values() []Slot {
+	if s.SlotValue != nil && s.SlotValue.Type == ListSlotValueType {
+		vs := make([]Slot, 0, len(s.SlotValue.Values))
+		for _, v := range s.SlotValue.Values {
+			vs = append(vs, Slot{
+				Name:               s.Name,
+				Value:              v.Value,
+				ConfirmationStatus: s.ConfirmationStatus,
+				Source:             s.Source,
+				Resolutions:        v.Resolutions,
+				SlotValue:          &SlotValue{Type: SimpleSlotValueType, Value: v.Value, Resolutions: v.Resolutions},
+			})
+		}
+		return vs
+	}
+
+	if s.SlotValue != nil && s.Value == "" {
+		s.Value = s.SlotValue.Value
+	}
+	if s.SlotValue != nil && len(s.Resolutions.ResolutionsPerAuthority) == 0 {
+		s.Resolutions = s.SlotValue.Resolutions
+	}
+	if s.Value == "" {
+		return nil
+	}
+	return []Slot{s}
+}
+
+// value returns the slot holding the single value of s. A Simple slotValue is
+// used when the slot has no value of its own.
+func (s Slot) value() (Slot, error) {
+	if s.SlotValue != nil && s.SlotValue.Type == ListSlotValueType {
+		if len(s.SlotValue.Values) == 0 {
+			return s, ErrSlotNotFilled
+		}
+		return s, ErrSlotMultipleValues
+	}
+
+	vs := s.Values()
+	if len(vs) == 0 {
+		return s, ErrSlotNotFilled
+	}
+	return vs[0], nil
+}
+
+// Number returns the value of an AMAZON.NUMBER slot.
+func (s Slot) Number() (int, error) {
+	s, err := s.value()
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := strconv.Atoi(s.Value)
+	if err != nil {
+		return 0, s.error(NumberSlotType, err)
+	}
+	return n, nil
+}
+
+// FourDigitNumber returns the value of an AMAZON.FOUR_DIGIT_NUMBER slot. Any
+// leading zeros are kept by the Value of the slot.
+func (s Slot) FourDigitNumber() (int, error) {
+	s, err := s.value()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(s.Value) != 4 {
+		return 0, s.error(FourDigitNumberSlotType, errors.New("not four digits"))
+	}
+	for _, r := range s.Value {
+		if r < '0' || r > '9' {
+			return 0, s.error(FourDigitNumberSlotType, errors.New("not four digits"))
+		}
+	}
+
+	n, _ := strconv.Atoi(s.Value)
+	return n, nil
+}
+
+// A DateRange is the span of time an AMAZON.DATE value refers to. Start is
+// inclusive and End is exclusive so a single day spans 24 hours.
+type DateRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Date returns the days an AMAZON.DATE slot refers to in the location, such
+// as the time zone of the device. A nil location is treated as UTC. Values
+// without a year and PRESENT_REF are relative to the current time. See
+// amazontime.ParseDate for the values understood and the granularity of the
+// result.
+func (s Slot) Date(loc *time.Location) (DateRange, error) {
+	s, err := s.value()
+	if err != nil {
+		return DateRange{}, err
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	d, err := amazontime.ParseDate(s.Value, time.Now().In(loc))
+	if err != nil {
+		return DateRange{}, s.error(DateSlotType, err)
+	}
+	return DateRange{d.Start, d.End}, nil
+}
+
+// A TimeOfDay is the value of an AMAZON.TIME slot. When the customer asks for
+// a part of the day such as "this evening" Period is one of MO, AF, EV or NI
+// and Hour and Minute are zero.
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+	Period string
+}
+
+// Time returns the value of an AMAZON.TIME slot.
+func (s Slot) Time() (TimeOfDay, error) {
+	s, err := s.value()
+	if err != nil {
+		return TimeOfDay{}, err
+	}
+
+	switch s.Value {
+	case "MO", "AF", "EV", "NI":
+		return TimeOfDay{Period: s.Value}, nil
+	}
+
+	t, err := time.Parse("15:04", s.Value)
+	if err != nil {
+		return TimeOfDay{}, s.error(TimeSlotType, err)
+	}
+	return TimeOfDay{Hour: t.Hour(), Minute: t.Minute()}, nil
+}
+
+// Duration returns the value of an AMAZON.DURATION slot given in ISO 8601 such
+// as PT10M or P2D. Years and months have no fixed length so they are counted
+// as 365 and 30 days. Use amazontime.ParseDuration to keep each component.
+func (s Slot) Duration() (time.Duration, error) {
+	s, err := s.value()
+	if err != nil {
+		return 0, err
+	}
+
+	d, err := amazontime.ParseDuration(s.Value)
+	if err != nil {
+		return 0, s.error(DurationSlotType, err)
+	}
+	return d.Approximate(), nil
+}
+
+// error wraps the cause of a failed conversion to the slot type.
+func (s Slot) error(slotType string, err error) *SlotError {
+	if perr, ok := err.(*amazontime.ParseError); ok {
+		err = perr.Err
+	}
+	return &SlotError{Name: s.Name, Value: s.Value, Type: slotType, Err: err}
+}
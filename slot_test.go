@@ -0,0 +1,279 @@
+package alexa_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/benjic/alexa"
+)
+
+func TestSlotNumber(t *testing.T) {
+	cases := []struct {
+		value string
+		want  int
+		err   bool
+	}{
+		{"42", 42, false},
+		{"-3", -3, false},
+		{"?", 0, true},
+	}
+
+	for _, c := range cases {
+		got, err := alexa.Slot{Name: "count", Value: c.value}.Number()
+		if (err != nil) != c.err || got != c.want {
+			t.Errorf("Wanted %d, err %t for %q; got %d, %v", c.want, c.err, c.value, got, err)
+		}
+		if _, ok := err.(*alexa.SlotError); c.err && !ok {
+			t.Errorf("Wanted *SlotError for %q; got %T", c.value, err)
+		}
+	}
+
+	if _, err := (alexa.Slot{Name: "count"}).Number(); err != alexa.ErrSlotNotFilled {
+		t.Errorf("Wanted %s; got %v", alexa.ErrSlotNotFilled, err)
+	}
+}
+
+func TestSlotFourDigitNumber(t *testing.T) {
+	cases := []struct {
+		value string
+		want  int
+		err   bool
+	}{
+		{"2026", 2026, false},
+		{"0042", 42, false},
+		{"42", 0, true},
+		{"12a4", 0, true},
+	}
+
+	for _, c := range cases {
+		got, err := alexa.Slot{Name: "pin", Value: c.value}.FourDigitNumber()
+		if (err != nil) != c.err || got != c.want {
+			t.Errorf("Wanted %d, err %t for %q; got %d, %v", c.want, c.err, c.value, got, err)
+		}
+	}
+}
+
+func TestSlotDate(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	cases := []struct {
+		value      string
+		start, end time.Time
+	}{
+		{"2026-10-19", day(2026, 10, 19), day(2026, 10, 20)},
+		{"2026-10", day(2026, 10, 1), day(2026, 11, 1)},
+		{"2026", day(2026, 1, 1), day(2027, 1, 1)},
+		{"2026-XX", day(2026, 1, 1), day(2027, 1, 1)},
+		{"201X", day(2010, 1, 1), day(2020, 1, 1)},
+		{"2026-W42", day(2026, 10, 12), day(2026, 10, 19)},
+		{"2026-W42-WE", day(2026, 10, 17), day(2026, 10, 19)},
+		{"2021-W01", day(2021, 1, 4), day(2021, 1, 11)},
+	}
+
+	for _, c := range cases {
+		got, err := alexa.Slot{Name: "date", Value: c.value}.Date(time.UTC)
+		if err != nil {
+			t.Errorf("Did not want err for %q; got %s", c.value, err)
+			continue
+		}
+		if !got.Start.Equal(c.start) || !got.End.Equal(c.end) {
+			t.Errorf("Wanted %s to %s for %q; got %s to %s", c.start, c.end, c.value, got.Start, got.End)
+		}
+	}
+
+	got, err := alexa.Slot{Name: "date", Value: "2026-10-19"}.Date(nil)
+	if err != nil || got.Start.Location() != time.UTC || !got.Start.Equal(day(2026, 10, 19)) {
+		t.Errorf("Wanted a nil location to be treated as UTC; got %s and %v", got.Start, err)
+	}
+
+	for _, value := range []string{"tomorrow", "2026-W60", "2026-13"} {
+		if _, err := (alexa.Slot{Name: "date", Value: value}).Date(time.UTC); err == nil {
+			t.Errorf("Wanted err for %q", value)
+		}
+	}
+}
+
+func TestSlotTime(t *testing.T) {
+	cases := []struct {
+		value string
+		want  alexa.TimeOfDay
+		err   bool
+	}{
+		{"14:25", alexa.TimeOfDay{Hour: 14, Minute: 25}, false},
+		{"00:00", alexa.TimeOfDay{}, false},
+		{"EV", alexa.TimeOfDay{Period: "EV"}, false},
+		{"25:00", alexa.TimeOfDay{}, true},
+	}
+
+	for _, c := range cases {
+		got, err := alexa.Slot{Name: "time", Value: c.value}.Time()
+		if (err != nil) != c.err || got != c.want {
+			t.Errorf("Wanted %+v, err %t for %q; got %+v, %v", c.want, c.err, c.value, got, err)
+		}
+	}
+}
+
+func TestSlotDuration(t *testing.T) {
+	cases := []struct {
+		value string
+		want  time.Duration
+		err   bool
+	}{
+		{"PT10M", 10 * time.Minute, false},
+		{"PT1H30M", 90 * time.Minute, false},
+		{"PT0.5S", 500 * time.Millisecond, false},
+		{"P2D", 48 * time.Hour, false},
+		{"P1W", 7 * 24 * time.Hour, false},
+		{"P1DT2H", 26 * time.Hour, false},
+		{"P", 0, true},
+		{"PT", 0, true},
+		{"10 minutes", 0, true},
+	}
+
+	for _, c := range cases {
+		got, err := alexa.Slot{Name: "duration", Value: c.value}.Duration()
+		if (err != nil) != c.err || got != c.want {
+			t.Errorf("Wanted %s, err %t for %q; got %s, %v", c.want, c.err, c.value, got, err)
+		}
+	}
+}
+
+func TestIntentRequestSlot(t *testing.T) {
+	req := &alexa.IntentRequest{}
+	req.Request.Intent.Slots = map[string]alexa.Slot{
+		"sign": {Name: "sign", Value: "virgo"},
+		"date": {Name: "date"},
+	}
+
+	if s := req.Slot("sign"); !s.Filled() || s.Value != "virgo" {
+		t.Errorf("Wanted filled sign slot; got %+v", s)
+	}
+	if s := req.Slot("date"); s.Filled() {
+		t.Errorf("Did not want filled date slot; got %+v", s)
+	}
+	if s := req.Slot("missing"); s.Filled() || s.Name != "missing" {
+		t.Errorf("Wanted unfilled missing slot; got %+v", s)
+	}
+}
+
+func TestSlotValues(t *testing.T) {
+	req := &alexa.IntentRequest{}
+	err := json.Unmarshal([]byte(`{
+		"request": {
+			"type": "IntentRequest",
+			"intent": {
+				"name": "OrderIntent",
+				"slots": {
+					"drinks": {
+						"name": "drinks",
+						"confirmationStatus": "NONE",
+						"source": "USER",
+						"slotValue": {
+							"type": "List",
+							"values": [
+								{
+									"type": "Simple",
+									"value": "latte",
+									"resolutions": {
+										"resolutionsPerAuthority": [{
+											"authority": "amzn1.er-authority.echo-sdk.amzn1.ask.skill.TEST.Drink",
+											"status": {"code": "ER_SUCCESS_MATCH"},
+											"values": [{"value": {"name": "caffe latte", "id": "LATTE"}}]
+										}]
+									}
+								},
+								{
+									"type": "Simple",
+									"value": "two",
+									"resolutions": {
+										"resolutionsPerAuthority": [{
+											"authority": "amzn1.er-authority.echo-sdk.amzn1.ask.skill.TEST.Drink",
+											"status": {"code": "ER_SUCCESS_NO_MATCH"}
+										}]
+									}
+								}
+							]
+						}
+					},
+					"size": {
+						"name": "size",
+						"value": "large",
+						"confirmationStatus": "NONE",
+						"source": "USER",
+						"slotValue": {
+							"type": "Simple",
+							"value": "large"
+						}
+					},
+					"note": {
+						"name": "note",
+						"confirmationStatus": "NONE"
+					}
+				}
+			}
+		}
+	}`), req)
+	if err != nil {
+		t.Fatalf("failed to unmarshal request: %s", err)
+	}
+
+	drinks := req.Slot("drinks")
+	if !drinks.Filled() {
+		t.Errorf("Wanted filled drinks slot; got %+v", drinks)
+	}
+
+	vs := drinks.Values()
+	if len(vs) != 2 || vs[0].Value != "latte" || vs[1].Value != "two" {
+		t.Fatalf("Unexpected values %+v", vs)
+	}
+	if m, err := vs[0].Match(); err != nil || m.ID != "LATTE" {
+		t.Errorf("Wanted LATTE match; got %+v, %v", m, err)
+	}
+	if _, err := vs[1].Match(); err != alexa.ErrEntityNoMatch {
+		t.Errorf("Wanted %s; got %v", alexa.ErrEntityNoMatch, err)
+	}
+
+	if vs := req.Slot("size").Values(); len(vs) != 1 || vs[0].Value != "large" {
+		t.Errorf("Wanted single large value; got %+v", vs)
+	}
+	if vs := req.Slot("note").Values(); len(vs) != 0 {
+		t.Errorf("Did not want values; got %+v", vs)
+	}
+}
+
+func TestSlotValueConversions(t *testing.T) {
+	simple := alexa.Slot{Name: "count", SlotValue: &alexa.SlotValue{Type: alexa.SimpleSlotValueType, Value: "3"}}
+	if n, err := simple.Number(); err != nil || n != 3 {
+		t.Errorf("Wanted 3 from the simple slotValue; got %d, %v", n, err)
+	}
+	if d, err := simple.Duration(); err == nil {
+		t.Errorf("Wanted err converting 3 to a duration; got %s", d)
+	} else if serr, ok := err.(*alexa.SlotError); !ok || serr.Value != "3" {
+		t.Errorf("Wanted *SlotError for value 3; got %v", err)
+	}
+
+	list := alexa.Slot{Name: "counts", SlotValue: &alexa.SlotValue{
+		Type: alexa.ListSlotValueType,
+		Values: []alexa.SlotValue{
+			{Type: alexa.SimpleSlotValueType, Value: "1"},
+			{Type: alexa.SimpleSlotValueType, Value: "2"},
+		},
+	}}
+	if _, err := list.Number(); err != alexa.ErrSlotMultipleValues {
+		t.Errorf("Wanted %s; got %v", alexa.ErrSlotMultipleValues, err)
+	}
+	if _, err := list.Date(time.UTC); err != alexa.ErrSlotMultipleValues {
+		t.Errorf("Wanted %s; got %v", alexa.ErrSlotMultipleValues, err)
+	}
+	if n, err := list.Values()[1].Number(); err != nil || n != 2 {
+		t.Errorf("Wanted 2 from the second value; got %d, %v", n, err)
+	}
+
+	empty := alexa.Slot{Name: "counts", SlotValue: &alexa.SlotValue{Type: alexa.ListSlotValueType}}
+	if _, err := empty.Number(); err != alexa.ErrSlotNotFilled {
+		t.Errorf("Wanted %s; got %v", alexa.ErrSlotNotFilled, err)
+	}
+}
@@ -0,0 +1,253 @@
+// Package ssml builds and validates the Speech Synthesis Markup Language
+// documents accepted by Alexa.
+//
+// Documents are built from nodes so text is always escaped and every tag is
+// closed:
+//
+//	doc := ssml.Speak(
+//		ssml.Text("Your order of fish & chips is "),
+//		ssml.Emphasis(ssml.StrongEmphasis, ssml.Text("ready")),
+//		ssml.Break(500*time.Millisecond),
+//		ssml.Whisper(ssml.Text("enjoy")),
+//	)
+//	resp.SSML(doc.String())
+//
+// Validate checks markup written by hand, or built with attribute values that
+// were not known in advance, against the tags and attributes Alexa supports.
+//
+// https://developer.amazon.com/docs/custom-skills/speech-synthesis-markup-language-ssml-reference.html
+package ssml
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The levels of an emphasis tag.
+const (
+	StrongEmphasis   = "strong"
+	ModerateEmphasis = "moderate"
+	ReducedEmphasis  = "reduced"
+)
+
+// The strengths of a break tag.
+const (
+	NoBreak      = "none"
+	XWeakBreak   = "x-weak"
+	WeakBreak    = "weak"
+	MediumBreak  = "medium"
+	StrongBreak  = "strong"
+	XStrongBreak = "x-strong"
+)
+
+// The speaking styles of an amazon:domain tag.
+const (
+	ConversationalDomain = "conversational"
+	LongFormDomain       = "long-form"
+	MusicDomain          = "music"
+	NewsDomain           = "news"
+	FunDomain            = "fun"
+)
+
+// The emotions and intensities of an amazon:emotion tag.
+const (
+	ExcitedEmotion      = "excited"
+	DisappointedEmotion = "disappointed"
+
+	LowIntensity    = "low"
+	MediumIntensity = "medium"
+	HighIntensity   = "high"
+)
+
+// WhisperedEffect is the only effect of an amazon:effect tag.
+const WhisperedEffect = "whispered"
+
+var escaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+	"'", "&apos;",
+)
+
+// Escape returns s with the characters that are special in SSML replaced by
+// entities so it can be placed in a document as text.
+func Escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// A Node is a piece of an SSML document.
+type Node interface {
+	// String returns the markup of the node.
+	String() string
+
+	write(b *strings.Builder)
+}
+
+type text string
+
+func (t text) String() string { return escaper.Replace(string(t)) }
+
+func (t text) write(b *strings.Builder) { escaper.WriteString(b, string(t)) }
+
+type attr struct {
+	name, value string
+}
+
+type element struct {
+	name     string
+	attrs    []attr
+	children []Node
+}
+
+func (e *element) String() string {
+	var b strings.Builder
+	e.write(&b)
+	return b.String()
+}
+
+func (e *element) write(b *strings.Builder) {
+	b.WriteString("<" + e.name)
+	for _, a := range e.attrs {
+		if a.value == "" {
+			continue
+		}
+		b.WriteString(" " + a.name + `="`)
+		escaper.WriteString(b, a.value)
+		b.WriteString(`"`)
+	}
+
+	if len(e.children) == 0 && emptyElements[e.name] {
+		b.WriteString("/>")
+		return
+	}
+
+	b.WriteString(">")
+	for _, c := range e.children {
+		c.write(b)
+	}
+	b.WriteString("</" + e.name + ">")
+}
+
+func newElement(name string, attrs []attr, children []Node) Node {
+	return &element{name: name, attrs: attrs, children: children}
+}
+
+// Speak returns the root of a document.
+func Speak(children ...Node) Node {
+	return newElement("speak", nil, children)
+}
+
+// Text returns plain text to be spoken. Characters with a meaning in markup
+// are escaped.
+func Text(s string) Node {
+	return text(s)
+}
+
+// Break pauses speech for the duration, which is at most ten seconds.
+func Break(d time.Duration) Node {
+	return newElement("break", []attr{{"time", strconv.FormatInt(d.Nanoseconds()/int64(time.Millisecond), 10) + "ms"}}, nil)
+}
+
+// BreakStrength pauses speech for as long as the strength, such as
+// MediumBreak, suggests.
+func BreakStrength(strength string) Node {
+	return newElement("break", []attr{{"strength", strength}}, nil)
+}
+
+// Emphasis speaks the children louder and slower, or quieter and faster for
+// ReducedEmphasis.
+func Emphasis(level string, children ...Node) Node {
+	return newElement("emphasis", []attr{{"level", level}}, children)
+}
+
+// ProsodyAttrs changes the rate, pitch and volume of speech. Empty fields are
+// left unchanged.
+type ProsodyAttrs struct {
+	// Rate is x-slow, slow, medium, fast, x-fast or a percentage of at
+	// least 20%.
+	Rate string
+	// Pitch is x-low, low, medium, high, x-high or a relative percentage
+	// between -33.3% and +50%.
+	Pitch string
+	// Volume is silent, x-soft, soft, medium, loud, x-loud or a relative
+	// change in decibels such as +6dB.
+	Volume string
+}
+
+// Prosody speaks the children with the attributes.
+func Prosody(p ProsodyAttrs, children ...Node) Node {
+	return newElement("prosody", []attr{{"rate", p.Rate}, {"pitch", p.Pitch}, {"volume", p.Volume}}, children)
+}
+
+// SayAs speaks the text as the interpretation, such as cardinal, date or
+// spell-out. The format is optional and refines how dates are read.
+func SayAs(interpretAs, format, s string) Node {
+	return newElement("say-as", []attr{{"interpret-as", interpretAs}, {"format", format}}, []Node{Text(s)})
+}
+
+// Phoneme pronounces the text with the phonetic spelling ph in the alphabet,
+// either ipa or x-sampa.
+func Phoneme(alphabet, ph, s string) Node {
+	return newElement("phoneme", []attr{{"alphabet", alphabet}, {"ph", ph}}, []Node{Text(s)})
+}
+
+// Audio plays the MP3 at the HTTPS src or the soundbank:// src of a sound from
+// the Alexa Skills Kit Sound Library.
+func Audio(src string) Node {
+	return newElement("audio", []attr{{"src", src}}, nil)
+}
+
+// Voice speaks the children with the named Amazon Polly voice.
+func Voice(name string, children ...Node) Node {
+	return newElement("voice", []attr{{"name", name}}, children)
+}
+
+// Lang speaks the children with the pronunciation of the language, such as
+// fr-FR.
+func Lang(lang string, children ...Node) Node {
+	return newElement("lang", []attr{{"xml:lang", lang}}, children)
+}
+
+// Effect applies the named effect to the children.
+func Effect(name string, children ...Node) Node {
+	return newElement("amazon:effect", []attr{{"name", name}}, children)
+}
+
+// Whisper whispers the children.
+func Whisper(children ...Node) Node {
+	return Effect(WhisperedEffect, children...)
+}
+
+// Domain speaks the children in the style of the named domain, such as
+// NewsDomain.
+func Domain(name string, children ...Node) Node {
+	return newElement("amazon:domain", []attr{{"name", name}}, children)
+}
+
+// Emotion speaks the children with the emotion at the intensity.
+func Emotion(name, intensity string, children ...Node) Node {
+	return newElement("amazon:emotion", []attr{{"name", name}, {"intensity", intensity}}, children)
+}
+
+// Paragraph pauses speech around the children as if reading a paragraph.
+func Paragraph(children ...Node) Node {
+	return newElement("p", nil, children)
+}
+
+// Sentence pauses speech around the children as if reading a sentence.
+func Sentence(children ...Node) Node {
+	return newElement("s", nil, children)
+}
+
+// Sub speaks the alias in place of the text.
+func Sub(alias, s string) Node {
+	return newElement("sub", []attr{{"alias", alias}}, []Node{Text(s)})
+}
+
+// Word pronounces the text with the sense of the role, such as amazon:VBD for
+// the past tense of a verb.
+func Word(role, s string) Node {
+	return newElement("w", []attr{{"role", role}}, []Node{Text(s)})
+}
@@ -0,0 +1,49 @@
+package ssml_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benjic/alexa/ssml"
+)
+
+func TestBuilder(t *testing.T) {
+	cases := []struct {
+		node ssml.Node
+		want string
+	}{
+		{ssml.Speak(ssml.Text("Fish & chips <3")), `<speak>Fish &amp; chips &lt;3</speak>`},
+		{ssml.Speak(ssml.Break(1500 * time.Millisecond)), `<speak><break time="1500ms"/></speak>`},
+		{ssml.Speak(ssml.BreakStrength(ssml.StrongBreak)), `<speak><break strength="strong"/></speak>`},
+		{ssml.Emphasis(ssml.StrongEmphasis, ssml.Text("really")), `<emphasis level="strong">really</emphasis>`},
+		{ssml.Prosody(ssml.ProsodyAttrs{Rate: "slow", Volume: "+6dB"}, ssml.Text("hi")), `<prosody rate="slow" volume="+6dB">hi</prosody>`},
+		{ssml.SayAs("date", "md", "12/25"), `<say-as interpret-as="date" format="md">12/25</say-as>`},
+		{ssml.SayAs("spell-out", "", "abc"), `<say-as interpret-as="spell-out">abc</say-as>`},
+		{ssml.Phoneme("ipa", "pɪˈkɑːn", "pecan"), `<phoneme alphabet="ipa" ph="pɪˈkɑːn">pecan</phoneme>`},
+		{ssml.Audio("https://example.com/a.mp3?x=1&y=2"), `<audio src="https://example.com/a.mp3?x=1&amp;y=2"/>`},
+		{ssml.Voice("Kendra", ssml.Text("hello")), `<voice name="Kendra">hello</voice>`},
+		{ssml.Lang("fr-FR", ssml.Text("bonjour")), `<lang xml:lang="fr-FR">bonjour</lang>`},
+		{ssml.Whisper(ssml.Text("psst")), `<amazon:effect name="whispered">psst</amazon:effect>`},
+		{ssml.Domain(ssml.NewsDomain, ssml.Text("today")), `<amazon:domain name="news">today</amazon:domain>`},
+		{ssml.Emotion(ssml.ExcitedEmotion, ssml.HighIntensity, ssml.Text("yes")), `<amazon:emotion name="excited" intensity="high">yes</amazon:emotion>`},
+		{ssml.Paragraph(ssml.Sentence(ssml.Text("One.")), ssml.Sentence(ssml.Text("Two."))), `<p><s>One.</s><s>Two.</s></p>`},
+		{ssml.Sub("aluminium", "Al"), `<sub alias="aluminium">Al</sub>`},
+		{ssml.Word("amazon:VBD", "read"), `<w role="amazon:VBD">read</w>`},
+		{ssml.Text(`"it's"`), `&quot;it&apos;s&quot;`},
+	}
+
+	for _, c := range cases {
+		if got := c.node.String(); got != c.want {
+			t.Errorf("Wanted %s; got %s", c.want, got)
+		}
+
+		doc := c.node.String()
+		if !strings.HasPrefix(doc, "<speak>") {
+			doc = ssml.Speak(c.node).String()
+		}
+		if err := ssml.Validate(doc); err != nil {
+			t.Errorf("Did not want err for %s; got %s", doc, err)
+		}
+	}
+}
@@ -0,0 +1,336 @@
+package ssml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxBreak = 10 * time.Second
+
+	minRate  = 20.0
+	minPitch = -33.3
+	maxPitch = 50.0
+)
+
+// An Error describes the first problem found in a document by Validate.
+type Error struct {
+	// Offset is the byte offset into the document the problem was found
+	// at.
+	Offset int64
+	// Element is the name of the tag with the problem, if any.
+	Element string
+	Reason  string
+}
+
+func (e *Error) Error() string {
+	if e.Element == "" {
+		return fmt.Sprintf("ssml: %s at offset %d", e.Reason, e.Offset)
+	}
+	return fmt.Sprintf("ssml: <%s>: %s at offset %d", e.Element, e.Reason, e.Offset)
+}
+
+// emptyElements must not have content.
+var emptyElements = map[string]bool{
+	"break": true,
+	"audio": true,
+}
+
+// An attrRule checks the value of an attribute. It returns a reason the value
+// is invalid or an empty string.
+type attrRule func(value string) string
+
+// An elementRule lists the attributes of a tag and which are required.
+type elementRule struct {
+	attrs    map[string]attrRule
+	required []string
+}
+
+var (
+	percentPattern  = regexp.MustCompile(`^(\d+(?:\.\d+)?)%$`)
+	relativePattern = regexp.MustCompile(`^([+-]\d+(?:\.\d+)?)%$`)
+	decibelPattern  = regexp.MustCompile(`^[+-]\d+(?:\.\d+)?dB$`)
+	breakPattern    = regexp.MustCompile(`^(\d+(?:\.\d+)?)(ms|s)$`)
+)
+
+var elementRules = map[string]elementRule{
+	"speak": {},
+	"p":     {},
+	"s":     {},
+	"break": {attrs: map[string]attrRule{
+		"strength": oneOf(NoBreak, XWeakBreak, WeakBreak, MediumBreak, StrongBreak, XStrongBreak),
+		"time":     breakTime,
+	}},
+	"emphasis": {attrs: map[string]attrRule{
+		"level": oneOf(StrongEmphasis, ModerateEmphasis, ReducedEmphasis),
+	}},
+	"prosody": {attrs: map[string]attrRule{
+		"rate":   rate,
+		"pitch":  pitch,
+		"volume": volume,
+	}},
+	"say-as": {
+		attrs: map[string]attrRule{
+			"interpret-as": oneOf("characters", "spell-out", "cardinal", "number", "ordinal", "digits", "fraction", "unit", "date", "time", "telephone", "address", "interjection", "expletive"),
+			"format":       oneOf("mdy", "dmy", "ymd", "md", "dm", "ym", "my", "d", "m", "y"),
+		},
+		required: []string{"interpret-as"},
+	},
+	"phoneme": {
+		attrs: map[string]attrRule{
+			"alphabet": oneOf("ipa", "x-sampa"),
+			"ph":       nonEmpty,
+		},
+		required: []string{"ph"},
+	},
+	"audio": {
+		attrs:    map[string]attrRule{"src": audioURL},
+		required: []string{"src"},
+	},
+	"sub": {
+		attrs:    map[string]attrRule{"alias": nonEmpty},
+		required: []string{"alias"},
+	},
+	"w": {
+		attrs:    map[string]attrRule{"role": oneOf("amazon:VB", "amazon:VBD", "amazon:NN", "amazon:SENSE_1")},
+		required: []string{"role"},
+	},
+	"voice": {
+		attrs:    map[string]attrRule{"name": nonEmpty},
+		required: []string{"name"},
+	},
+	"lang": {
+		attrs:    map[string]attrRule{"xml:lang": oneOf("en-US", "en-GB", "en-IN", "en-AU", "en-CA", "de-DE", "es-ES", "es-MX", "es-US", "fr-CA", "fr-FR", "hi-IN", "it-IT", "ja-JP", "pt-BR")},
+		required: []string{"xml:lang"},
+	},
+	"amazon:effect": {
+		attrs:    map[string]attrRule{"name": oneOf(WhisperedEffect)},
+		required: []string{"name"},
+	},
+	"amazon:domain": {
+		attrs:    map[string]attrRule{"name": oneOf(ConversationalDomain, LongFormDomain, MusicDomain, NewsDomain, FunDomain)},
+		required: []string{"name"},
+	},
+	"amazon:emotion": {
+		attrs: map[string]attrRule{
+			"name":      oneOf(ExcitedEmotion, DisappointedEmotion),
+			"intensity": oneOf(LowIntensity, MediumIntensity, HighIntensity),
+		},
+		required: []string{"name", "intensity"},
+	},
+}
+
+// Validate reports the first problem with the document that would cause
+// Alexa to reject it. The document must be well formed with a single speak
+// root, use only the tags and attributes Alexa supports with valid values,
+// and give no content to break and audio tags. A nil error is returned for a
+// valid document.
+func Validate(doc string) error {
+	d := xml.NewDecoder(strings.NewReader(doc))
+	d.Strict = true
+
+	var stack []string
+	roots := 0
+
+	for {
+		offset := d.InputOffset()
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return &Error{Offset: offset, Reason: err.Error()}
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			name := qualifiedName(t.Name)
+			if len(stack) > 0 && emptyElements[stack[len(stack)-1]] {
+				return &Error{Offset: offset, Element: stack[len(stack)-1], Reason: "must be empty"}
+			}
+			if name == "speak" {
+				if len(stack) > 0 || roots > 0 {
+					return &Error{Offset: offset, Element: name, Reason: "must be the only root"}
+				}
+				roots++
+			} else if len(stack) == 0 {
+				return &Error{Offset: offset, Element: name, Reason: "must be inside <speak>"}
+			}
+			if err := validateElement(name, t.Attr); err != "" {
+				return &Error{Offset: offset, Element: name, Reason: err}
+			}
+			stack = append(stack, name)
+
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+
+		case xml.CharData:
+			if strings.TrimSpace(string(t)) == "" {
+				continue
+			}
+			if len(stack) == 0 {
+				return &Error{Offset: offset, Reason: "text must be inside <speak>"}
+			}
+			if top := stack[len(stack)-1]; emptyElements[top] {
+				return &Error{Offset: offset, Element: top, Reason: "must be empty"}
+			}
+
+		case xml.ProcInst, xml.Directive:
+			return &Error{Offset: offset, Reason: "unsupported markup"}
+		}
+	}
+
+	if roots == 0 {
+		return &Error{Offset: d.InputOffset(), Reason: "missing <speak>"}
+	}
+	return nil
+}
+
+// validateElement returns a reason the tag or its attributes are invalid or
+// an empty string.
+func validateElement(name string, attrs []xml.Attr) string {
+	rule, ok := elementRules[name]
+	if !ok {
+		return "unsupported tag"
+	}
+
+	seen := map[string]bool{}
+	for _, a := range attrs {
+		attrName := qualifiedName(a.Name)
+		check, ok := rule.attrs[attrName]
+		if !ok {
+			return fmt.Sprintf("unsupported attribute %s", attrName)
+		}
+		if reason := check(a.Value); reason != "" {
+			return fmt.Sprintf("%s: %s", attrName, reason)
+		}
+		seen[attrName] = true
+	}
+
+	for _, r := range rule.required {
+		if !seen[r] {
+			return fmt.Sprintf("missing attribute %s", r)
+		}
+	}
+	return ""
+}
+
+// qualifiedName returns the name as written in the document. The decoder
+// reports undeclared prefixes as the space, except xml which it expands.
+func qualifiedName(n xml.Name) string {
+	switch n.Space {
+	case "":
+		return n.Local
+	case "http://www.w3.org/XML/1998/namespace":
+		return "xml:" + n.Local
+	}
+	return n.Space + ":" + n.Local
+}
+
+func oneOf(values ...string) attrRule {
+	return func(value string) string {
+		for _, v := range values {
+			if v == value {
+				return ""
+			}
+		}
+		return fmt.Sprintf("%q is not one of %s", value, strings.Join(values, ", "))
+	}
+}
+
+func nonEmpty(value string) string {
+	if value == "" {
+		return "must not be empty"
+	}
+	return ""
+}
+
+// audioURL accepts HTTPS URLs and sounds from the Alexa Skills Kit Sound
+// Library, which use the soundbank scheme.
+func audioURL(value string) string {
+	if !strings.HasPrefix(value, "https://") && !strings.HasPrefix(value, "soundbank://") {
+		return fmt.Sprintf("%q is not an HTTPS or soundbank URL", value)
+	}
+	return ""
+}
+
+func breakTime(value string) string {
+	m := breakPattern.FindStringSubmatch(value)
+	if m == nil {
+		return fmt.Sprintf("%q is not a time such as 500ms or 3s", value)
+	}
+
+	n, _ := strconv.ParseFloat(m[1], 64)
+	unit := time.Second
+	if m[2] == "ms" {
+		unit = time.Millisecond
+	}
+	if time.Duration(n*float64(unit)) > maxBreak {
+		return fmt.Sprintf("%q is longer than %s", value, maxBreak)
+	}
+	return ""
+}
+
+func rate(value string) string {
+	if oneOf("x-slow", "slow", "medium", "fast", "x-fast")(value) == "" {
+		return ""
+	}
+	m := percentPattern.FindStringSubmatch(value)
+	if m == nil {
+		return fmt.Sprintf("%q is not a rate such as slow or 80%%", value)
+	}
+	if n, _ := strconv.ParseFloat(m[1], 64); n < minRate {
+		return fmt.Sprintf("%q is less than %g%%", value, minRate)
+	}
+	return ""
+}
+
+func pitch(value string) string {
+	if oneOf("x-low", "low", "medium", "high", "x-high")(value) == "" {
+		return ""
+	}
+	m := relativePattern.FindStringSubmatch(value)
+	if m == nil {
+		return fmt.Sprintf("%q is not a pitch such as low or +10%%", value)
+	}
+	if n, _ := strconv.ParseFloat(m[1], 64); n < minPitch || n > maxPitch {
+		return fmt.Sprintf("%q is outside %g%% to +%g%%", value, minPitch, maxPitch)
+	}
+	return ""
+}
+
+func volume(value string) string {
+	if oneOf("silent", "x-soft", "soft", "medium", "loud", "x-loud")(value) == "" {
+		return ""
+	}
+	if !decibelPattern.MatchString(value) {
+		return fmt.Sprintf("%q is not a volume such as loud or +6dB", value)
+	}
+	return ""
+}
+
+// CountElements returns the number of tags with the name, such as audio, in
+// the document. Malformed documents return the error of the decoder.
+func CountElements(doc, name string) (int, error) {
+	d := xml.NewDecoder(strings.NewReader(doc))
+	d.Strict = true
+
+	n := 0
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		if t, ok := tok.(xml.StartElement); ok && qualifiedName(t.Name) == name {
+			n++
+		}
+	}
+}
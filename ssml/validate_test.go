@@ -0,0 +1,103 @@
+package ssml_test
+
+import (
+	"testing"
+
+	"github.com/benjic/alexa/ssml"
+)
+
+func TestValidate(t *testing.T) {
+	valid := []string{
+		`<speak>Hello</speak>`,
+		` <speak>Hello <break time="3s"/> world</speak> `,
+		`<speak><break time="10s"/><break time="250ms"/><break strength="x-weak"/></speak>`,
+		`<speak><prosody rate="20%" pitch="-33.3%" volume="x-loud">a</prosody></speak>`,
+		`<speak><prosody rate="x-fast" pitch="+50%" volume="-6.5dB">a</prosody></speak>`,
+		`<speak><say-as interpret-as="cardinal">12345</say-as></speak>`,
+		`<speak><phoneme ph="pɪˈkɑːn">pecan</phoneme></speak>`,
+		`<speak><audio src="https://example.com/a.mp3"></audio></speak>`,
+		`<speak><audio src="soundbank://soundlibrary/animals/amzn_sfx_bear_groan_roar_01"/></speak>`,
+		`<speak><lang xml:lang="de-DE"><voice name="Hans">Hallo</voice></lang></speak>`,
+		`<speak><amazon:domain name="long-form"><amazon:effect name="whispered">a</amazon:effect></amazon:domain></speak>`,
+		`<speak><amazon:emotion name="disappointed" intensity="low">oh</amazon:emotion></speak>`,
+		`<speak><p><s><w role="amazon:NN">bass</w> <sub alias="magnesium">Mg</sub></s></p></speak>`,
+		`<speak>Fish &amp; chips</speak>`,
+	}
+
+	for _, doc := range valid {
+		if err := ssml.Validate(doc); err != nil {
+			t.Errorf("Did not want err for %s; got %s", doc, err)
+		}
+	}
+
+	invalid := []struct {
+		doc     string
+		element string
+	}{
+		{``, ""},
+		{`Hello`, ""},
+		{`<speak>Hello`, ""},
+		{`<speak>Fish & chips</speak>`, ""},
+		{`<speak>a</speak><speak>b</speak>`, "speak"},
+		{`<speak><speak>a</speak></speak>`, "speak"},
+		{`<break time="1s"/>`, "break"},
+		{`<speak>a</speak>b`, ""},
+		{`<speak><emphasis>a</speak></emphasis>`, ""},
+		{`<speak><blink>a</blink></speak>`, "blink"},
+		{`<speak><break time="11s"/></speak>`, "break"},
+		{`<speak><break time="1 second"/></speak>`, "break"},
+		{`<speak><break strength="huge"/></speak>`, "break"},
+		{`<speak><break time="1s">a</break></speak>`, "break"},
+		{`<speak><audio src="https://example.com/a.mp3"><break/></audio></speak>`, "audio"},
+		{`<speak><audio src="http://example.com/a.mp3"/></speak>`, "audio"},
+		{`<speak><audio src="file://soundlibrary/a.mp3"/></speak>`, "audio"},
+		{`<speak><audio/></speak>`, "audio"},
+		{`<speak><emphasis level="loud">a</emphasis></speak>`, "emphasis"},
+		{`<speak><emphasis colour="red">a</emphasis></speak>`, "emphasis"},
+		{`<speak><prosody rate="10%">a</prosody></speak>`, "prosody"},
+		{`<speak><prosody pitch="+60%">a</prosody></speak>`, "prosody"},
+		{`<speak><prosody pitch="10%">a</prosody></speak>`, "prosody"},
+		{`<speak><prosody volume="6dB">a</prosody></speak>`, "prosody"},
+		{`<speak><say-as>1</say-as></speak>`, "say-as"},
+		{`<speak><say-as interpret-as="roman">IV</say-as></speak>`, "say-as"},
+		{`<speak><phoneme alphabet="ipa">a</phoneme></speak>`, "phoneme"},
+		{`<speak><voice>a</voice></speak>`, "voice"},
+		{`<speak><lang xml:lang="xx-XX">a</lang></speak>`, "lang"},
+		{`<speak><amazon:effect name="shouted">a</amazon:effect></speak>`, "amazon:effect"},
+		{`<speak><amazon:domain name="sports">a</amazon:domain></speak>`, "amazon:domain"},
+		{`<speak><amazon:emotion name="excited">a</amazon:emotion></speak>`, "amazon:emotion"},
+		{`<speak><amazon:emotion name="angry" intensity="high">a</amazon:emotion></speak>`, "amazon:emotion"},
+		{`<speak><w role="noun">a</w></speak>`, "w"},
+		{`<?xml version="1.0"?><speak>a</speak>`, ""},
+	}
+
+	for _, c := range invalid {
+		err := ssml.Validate(c.doc)
+		serr, ok := err.(*ssml.Error)
+		if !ok {
+			t.Errorf("Wanted *Error for %s; got %v", c.doc, err)
+			continue
+		}
+		if serr.Element != c.element {
+			t.Errorf("Wanted error for <%s> in %s; got %s", c.element, c.doc, serr)
+		}
+	}
+}
+
+func TestCountElements(t *testing.T) {
+	doc := ssml.Speak(
+		ssml.Audio("https://example.com/a.mp3"),
+		ssml.Text("and"),
+		ssml.Whisper(ssml.Audio("https://example.com/b.mp3")),
+	).String()
+
+	if n, err := ssml.CountElements(doc, "audio"); err != nil || n != 2 {
+		t.Errorf("Wanted 2 audio tags; got %d, %v", n, err)
+	}
+	if n, err := ssml.CountElements(doc, "amazon:effect"); err != nil || n != 1 {
+		t.Errorf("Wanted 1 amazon:effect tag; got %d, %v", n, err)
+	}
+	if _, err := ssml.CountElements("<speak>", "audio"); err == nil {
+		t.Errorf("Wanted err for malformed document")
+	}
+}
@@ -0,0 +1,150 @@
+package alexa
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/benjic/alexa/ssml"
+)
+
+const (
+	maxSpeechLength = 8000
+	maxAudioTags    = 5
+	maxResponseSize = 24 * 1024
+)
+
+// A ValidationMode selects what a Handler does with a response Alexa would
+// reject.
+type ValidationMode int
+
+// The validation modes of a Handler.
+const (
+	// NoValidation sends every response as built.
+	NoValidation ValidationMode = iota
+	// WarnValidation logs the problems with a response but still sends it.
+	WarnValidation
+	// StrictValidation logs the problems with a response and responds with
+	// an internal server error instead.
+	StrictValidation
+)
+
+// A ValidationError lists every problem found with a response.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "alexa: invalid response: " + strings.Join(e.Problems, "; ")
+}
+
+// validate checks the response against the limits Alexa places on responses
+// to the request type. The size is the length of the encoded response.
+func (b *responseBuilder) validate(size int) error {
+	var problems []string
+	problem := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if size > maxResponseSize {
+		problem("response is %d bytes, more than %d", size, maxResponseSize)
+	}
+
+	r := b.Response
+	audioTags := 0
+	type namedSpeech struct {
+		name   string
+		speech *outputSpeech
+	}
+	speeches := []namedSpeech{{"outputSpeech", r.OutputSpeech}}
+	if r.Reprompt != nil {
+		speeches = append(speeches, namedSpeech{"reprompt", r.Reprompt.OutputSpeech})
+	}
+
+	for _, s := range speeches {
+		if s.speech == nil {
+			continue
+		}
+
+		var text string
+		switch {
+		case s.speech.Text != nil:
+			text = *s.speech.Text
+		case s.speech.SSML != nil:
+			text = *s.speech.SSML
+			if err := ssml.Validate(text); err != nil {
+				problem("%s: %s", s.name, err)
+			} else {
+				n, _ := ssml.CountElements(text, "audio")
+				audioTags += n
+			}
+		}
+
+		if n := utf8.RuneCountInString(text); n > maxSpeechLength {
+			problem("%s is %d characters, more than %d", s.name, n, maxSpeechLength)
+		}
+	}
+
+	if audioTags > maxAudioTags {
+		problem("speech has %d audio tags, more than %d", audioTags, maxAudioTags)
+	}
+
+	if c := r.Card; c != nil && c.Image != nil {
+		checkHTTPS(problem, "card smallImageUrl", c.Image.SmallImageURL)
+		checkHTTPS(problem, "card largeImageUrl", c.Image.LargeImageURL)
+	}
+
+	if d := r.Directives; d != nil {
+		if p := d.playDirective; p != nil {
+			checkHTTPS(problem, "audio stream url", p.AudioItem.Stream.URL)
+			if m := p.AudioItem.Metadata; m != nil {
+				checkImageHTTPS(problem, "audio art", m.Art)
+				checkImageHTTPS(problem, "audio backgroundImage", m.BackgroundImage)
+			}
+		}
+		if v := d.videoAppLaunchDirective; v != nil {
+			checkHTTPS(problem, "video source", v.VideoItem.Source)
+			if r.ShouldEndSession != nil {
+				problem("VideoApp.Launch responses cannot include shouldEndSession")
+			}
+		}
+	}
+
+	if b.requestType.audioCallback() {
+		if r.OutputSpeech != nil || r.Card != nil || r.Reprompt != nil {
+			problem("%s responses cannot include speech, cards or reprompts", b.requestType)
+		}
+		if r.ShouldEndSession != nil {
+			problem("%s responses cannot include shouldEndSession", b.requestType)
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// audioCallback reports whether the request type is sent by the AudioPlayer
+// or PlaybackController interfaces, which only accept audio directives in
+// response.
+func (t RequestType) audioCallback() bool {
+	return strings.HasPrefix(string(t), "AudioPlayer.") || strings.HasPrefix(string(t), "PlaybackController.")
+}
+
+// checkHTTPS reports a URL that is set but not served over HTTPS.
+func checkHTTPS(problem func(string, ...interface{}), name, url string) {
+	if url != "" && !strings.HasPrefix(url, "https://") {
+		problem("%s %q is not an HTTPS URL", name, url)
+	}
+}
+
+// checkImageHTTPS reports every source of the image not served over HTTPS.
+func checkImageHTTPS(problem func(string, ...interface{}), name string, img *Image) {
+	if img == nil {
+		return
+	}
+	for _, s := range img.Sources {
+		checkHTTPS(problem, name, s.URL)
+	}
+}
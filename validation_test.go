@@ -0,0 +1,126 @@
+package alexa
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResponseValidate(t *testing.T) {
+	longText := strings.Repeat("a", maxSpeechLength+1)
+	audio := strings.Repeat(`<audio src="https://example.com/a.mp3"/>`, 3)
+
+	cases := []struct {
+		name        string
+		requestType RequestType
+		build       func(b *responseBuilder)
+		size        int
+		problems    int
+	}{
+		{"valid speech", IntentRequestType, func(b *responseBuilder) {
+			b.SSML("<speak>Hello <break time=\"1s\"/></speak>")
+			b.RepromptPlainText("Still there?")
+			b.StandardCard("Title", "Text", "https://example.com/s.png", "https://example.com/l.png")
+		}, 100, 0},
+		{"long speech", IntentRequestType, func(b *responseBuilder) {
+			b.PlainText(longText)
+			b.RepromptPlainText(longText)
+		}, 100, 2},
+		{"malformed ssml", IntentRequestType, func(b *responseBuilder) {
+			b.SSML("<speak>Hello")
+		}, 100, 1},
+		{"too many audio tags", IntentRequestType, func(b *responseBuilder) {
+			b.SSML("<speak>" + audio + "</speak>")
+			b.RepromptSSML("<speak>" + audio + "</speak>")
+		}, 100, 1},
+		{"insecure urls", IntentRequestType, func(b *responseBuilder) {
+			b.StandardCard("Title", "Text", "http://example.com/s.png", "")
+			b.ReplaceAllAudio(PlayRequest{
+				Token: "t",
+				URL:   "http://example.com/a.mp3",
+				Metadata: &AudioItemMetadata{
+					Art: &Image{Sources: []ImageSource{{URL: "http://example.com/art.png"}}},
+				},
+			})
+		}, 100, 3},
+		{"oversized", IntentRequestType, func(b *responseBuilder) {
+			b.PlainText("Hello")
+		}, maxResponseSize + 1, 1},
+		{"audio callback with speech", AudioPlayerPlaybackNearlyFinishedType, func(b *responseBuilder) {
+			b.PlainText("Next up")
+			b.ShouldEndSession(true)
+			b.EnqueueAudio(PlayRequest{Token: "t2", URL: "https://example.com/b.mp3", ExpectedPreviousToken: "t"})
+		}, 100, 2},
+		{"video with shouldEndSession", IntentRequestType, func(b *responseBuilder) {
+			b.LaunchVideo("https://example.com/v.mp4", "Title", "")
+			b.ShouldEndSession(false)
+		}, 100, 1},
+		{"video", IntentRequestType, func(b *responseBuilder) {
+			b.ShouldEndSession(true)
+			b.LaunchVideo("https://example.com/v.mp4", "Title", "")
+		}, 100, 0},
+		{"audio callback", PlaybackControllerNextCommandIssuedType, func(b *responseBuilder) {
+			b.ReplaceAllAudio(PlayRequest{Token: "t", URL: "https://example.com/a.mp3"})
+		}, 100, 0},
+	}
+
+	for _, c := range cases {
+		b := &responseBuilder{Version: version, Response: &response{}, requestType: c.requestType}
+		b.device.SupportedInterfaces.VideoApp = &struct{}{}
+		c.build(b)
+
+		err := b.validate(c.size)
+		if c.problems == 0 {
+			if err != nil {
+				t.Errorf("%s: did not want err; got %s", c.name, err)
+			}
+			continue
+		}
+
+		verr, ok := err.(*ValidationError)
+		if !ok || len(verr.Problems) != c.problems {
+			t.Errorf("%s: wanted %d problems; got %v", c.name, c.problems, err)
+		}
+	}
+}
+
+func TestHandlerWriteResponse(t *testing.T) {
+	invalid := &responseBuilder{Version: version, Response: &response{}, requestType: IntentRequestType}
+	invalid.PlainText(strings.Repeat("a", maxSpeechLength+1))
+
+	cases := []struct {
+		mode     ValidationMode
+		status   int
+		reported bool
+	}{
+		{NoValidation, http.StatusOK, false},
+		{WarnValidation, http.StatusOK, true},
+		{StrictValidation, http.StatusInternalServerError, true},
+	}
+
+	for _, c := range cases {
+		var logs bytes.Buffer
+		var reported error
+		h := &Handler{
+			Validation:        c.mode,
+			OnInvalidResponse: func(err error) { reported = err },
+			ErrorLog:          log.New(&logs, "", 0),
+		}
+
+		w := httptest.NewRecorder()
+		h.writeResponse(w, invalid)
+
+		if w.Code != c.status {
+			t.Errorf("Wanted %d for mode %d; got %d", c.status, c.mode, w.Code)
+		}
+		if logged := logs.Len() > 0; logged != c.reported {
+			t.Errorf("Wanted logged %t for mode %d; got %q", c.reported, c.mode, logs.String())
+		}
+		if _, ok := reported.(*ValidationError); ok != c.reported {
+			t.Errorf("Wanted reported %t for mode %d; got %v", c.reported, c.mode, reported)
+		}
+	}
+}